@@ -0,0 +1,379 @@
+package main
+
+import (
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// runSaveInspect prints a readable summary of a save file
+func runSaveInspect(args []string) error {
+	fs := flag.NewFlagSet("save inspect", flag.ContinueOnError)
+	profileName := fs.String("profile", data.DefaultProfile, "profile to inspect when no path is given")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	if path == "" {
+		profiles, err := openProfiles(os.Stderr)
+		if err != nil {
+			return err
+		}
+		if !profiles.Exists(*profileName) {
+			return fmt.Errorf("profile %q not found", *profileName)
+		}
+		path = profiles.SaveManager(*profileName).SavePath
+	}
+
+	version, err := data.SchemaVersionOf(path)
+	if err != nil {
+		return err
+	}
+	player, err := data.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	printInspect(os.Stdout, path, version, player)
+	return nil
+}
+
+// runSaveDiff prints what changed between two save files
+func runSaveDiff(args []string) error {
+	fs := flag.NewFlagSet("save diff", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("save diff needs two save files\n%s", saveUsage)
+	}
+
+	before, err := data.LoadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	after, err := data.LoadFile(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(1), err)
+	}
+
+	fmt.Printf("%s -> %s\n\n", fs.Arg(0), fs.Arg(1))
+	printDiff(os.Stdout, before, after)
+	return nil
+}
+
+// printInspect writes the save summary
+func printInspect(out io.Writer, path string, version int, player *models.Player) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	schema := fmt.Sprintf("v%d", version)
+	if version != data.CurrentSchemaVersion {
+		schema += fmt.Sprintf(" (this build writes v%d)", data.CurrentSchemaVersion)
+	}
+
+	fmt.Fprintf(w, "Save:\t%s\n", path)
+	fmt.Fprintf(w, "Schema:\t%s\n", schema)
+	fmt.Fprintf(w, "Player:\t%s\n", player.Name)
+	fmt.Fprintf(w, "Created:\t%s\n", formatTime(player.CreatedAt))
+	fmt.Fprintf(w, "Last online:\t%s\n", formatTime(player.LastOnline))
+	fmt.Fprintf(w, "Clock:\t%s\n", clockStatus(player))
+	fmt.Fprintf(w, "Playtime:\t%s\n", models.FormatDuration(player.TotalPlaytime))
+	fmt.Fprintf(w, "Gold:\t%d\n", player.Gold)
+	fmt.Fprintf(w, "Total level:\t%d\n", player.GetTotalLevel())
+
+	fmt.Fprintln(w, "\nSkills")
+	for _, skillType := range sortedSkills(player) {
+		skill := player.Skills[skillType]
+		fmt.Fprintf(w, "  %s\tLv.%d\t%d / %d XP\t%d actions\n",
+			skillName(skillType), skill.Level, skill.XP, skill.XPToNext, skill.TotalProcessed)
+	}
+
+	if player.Attributes != nil {
+		fmt.Fprintln(w, "\nAttributes")
+		for _, attr := range attributeList(player.Attributes) {
+			fmt.Fprintf(w, "  %s\tLv.%d\t%d / %d XP\n", attr.name, attr.Level, attr.XP, attr.XPToNext)
+		}
+	}
+
+	if cs := player.CombatStats; cs != nil {
+		fmt.Fprintln(w, "\nCombat")
+		fmt.Fprintf(w, "  %s\n", cs.String())
+		fmt.Fprintf(w, "  Slayer Lv.%d, %d XP, %d points\n", cs.SlayerLevel, cs.SlayerXP, cs.SlayerPoints)
+		if task := cs.CurrentTask; task != nil {
+			fmt.Fprintf(w, "  Task: %s %d/%d\n", task.MonsterName, task.Killed, task.Amount)
+		}
+	}
+
+	fmt.Fprintln(w, "\nCurrent activity")
+	if activity := player.CurrentActivity; activity != nil {
+		fmt.Fprintf(w, "  %s\t%s\n", activity.Name, skillName(activity.SkillType))
+	} else {
+		fmt.Fprintln(w, "  none")
+	}
+
+	if player.Equipment != nil {
+		fmt.Fprintln(w, "\nEquipment")
+		for _, slot := range models.EquipmentSlots {
+			name := "-"
+			if item := player.Equipment.GetSlot(slot); item != nil {
+				name = item.Name
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", slot, name)
+		}
+	}
+
+	if inv := player.Inventory; inv != nil {
+		fmt.Fprintf(w, "\nInventory (%d/%d slots, worth %d gold)\n", inv.Count(), inv.MaxSlots, inv.GetTotalValue())
+		for _, item := range inv.Items {
+			fmt.Fprintf(w, "  %s\tx%d\t%d gold\n", item.Name, item.Quantity, item.Value*int64(item.Quantity))
+		}
+	}
+
+	fmt.Fprintf(w, "\nPerks (%d unlocked)\n", len(player.UnlockedPerks))
+	for _, perk := range player.UnlockedPerks {
+		fmt.Fprintf(w, "  %s\t%s\n", perk.Name, perk.Description)
+	}
+
+	if player.ActivityLog != nil {
+		fmt.Fprintf(w, "\nActivity log: %d entries\n", player.ActivityLog.GetEntryCount())
+	}
+}
+
+// printDiff writes the level, XP, item and gold changes from before to after
+func printDiff(out io.Writer, before, after *models.Player) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "Gold:\t%d -> %d\t%+d\n", before.Gold, after.Gold, after.Gold-before.Gold)
+	fmt.Fprintf(w, "Total level:\t%d -> %d\t%+d\n", before.GetTotalLevel(), after.GetTotalLevel(), after.GetTotalLevel()-before.GetTotalLevel())
+	fmt.Fprintf(w, "Playtime:\t%s -> %s\n", models.FormatDuration(before.TotalPlaytime), models.FormatDuration(after.TotalPlaytime))
+
+	var lines []string
+	for _, skillType := range sortedSkills(before, after) {
+		a, b := before.GetSkill(skillType), after.GetSkill(skillType)
+		if a.Level == b.Level && a.XP == b.XP {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s\tLv.%d -> %d\t%+d levels\t%+d XP\n",
+			skillName(skillType), a.Level, b.Level, b.Level-a.Level, b.TotalXP()-a.TotalXP()))
+	}
+	printSection(w, "Skills", lines)
+
+	lines = nil
+	if before.Attributes != nil && after.Attributes != nil {
+		beforeAttrs, afterAttrs := attributeList(before.Attributes), attributeList(after.Attributes)
+		for i, a := range beforeAttrs {
+			b := afterAttrs[i]
+			if a.Level == b.Level && a.XP == b.XP {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %s\tLv.%d -> %d\t%+d levels\t%d -> %d XP\n",
+				a.name, a.Level, b.Level, b.Level-a.Level, a.XP, b.XP))
+		}
+	}
+	printSection(w, "Attributes", lines)
+
+	lines = nil
+	beforeItems, afterItems := itemTotals(before), itemTotals(after)
+	for _, id := range sortedKeys(beforeItems, afterItems) {
+		a, b := beforeItems[id], afterItems[id]
+		if a.quantity == b.quantity {
+			continue
+		}
+		name := b.name
+		if name == "" {
+			name = a.name
+		}
+		lines = append(lines, fmt.Sprintf("  %s\t%d -> %d\t%+d\n", name, a.quantity, b.quantity, b.quantity-a.quantity))
+	}
+	printSection(w, "Items", lines)
+
+	lines = nil
+	for _, slot := range models.EquipmentSlots {
+		a, b := equippedName(before, slot), equippedName(after, slot)
+		if a != b {
+			lines = append(lines, fmt.Sprintf("  %s\t%s -> %s\n", slot, a, b))
+		}
+	}
+	printSection(w, "Equipment", lines)
+
+	lines = nil
+	hadPerk := make(map[string]bool)
+	for _, perk := range before.UnlockedPerks {
+		hadPerk[perk.ID] = true
+	}
+	for _, perk := range after.UnlockedPerks {
+		if !hadPerk[perk.ID] {
+			lines = append(lines, fmt.Sprintf("  %s\t%s\n", perk.Name, perk.Description))
+		}
+	}
+	printSection(w, "Perks gained", lines)
+
+	if a, b := activityName(before), activityName(after); a != b {
+		fmt.Fprintf(w, "\nActivity:\t%s -> %s\n", a, b)
+	}
+}
+
+// printSection writes a titled list, or nothing if it is empty
+func printSection(w io.Writer, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, line := range lines {
+		fmt.Fprint(w, line)
+	}
+}
+
+// namedAttribute is an attribute with its display name
+type namedAttribute struct {
+	name string
+	models.Attribute
+}
+
+// attributeList returns the attributes in display order
+func attributeList(attrs *models.CharacterAttributes) []namedAttribute {
+	return []namedAttribute{
+		{"Strength", attrs.Strength},
+		{"Dexterity", attrs.Dexterity},
+		{"Defense", attrs.Defense},
+		{"Constitution", attrs.Constitution},
+		{"Intelligence", attrs.Intelligence},
+	}
+}
+
+// itemTotal is the combined quantity of one item across a player's stacks
+type itemTotal struct {
+	name     string
+	quantity int
+}
+
+// itemTotals adds up inventory stacks by item ID
+func itemTotals(player *models.Player) map[string]itemTotal {
+	totals := make(map[string]itemTotal)
+	if player.Inventory == nil {
+		return totals
+	}
+	for _, item := range player.Inventory.Items {
+		total := totals[item.ID]
+		total.name = item.Name
+		total.quantity += item.Quantity
+		totals[item.ID] = total
+	}
+	return totals
+}
+
+// sortedKeys returns the keys of both maps, sorted
+func sortedKeys(maps ...map[string]itemTotal) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedSkills returns the skills of all players in display order,
+// followed by any skills this build doesn't know
+func sortedSkills(players ...*models.Player) []models.SkillType {
+	has := func(skillType models.SkillType) bool {
+		for _, player := range players {
+			if _, ok := player.Skills[skillType]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	var skills []models.SkillType
+	for _, skillType := range models.SkillTypes {
+		if has(skillType) {
+			skills = append(skills, skillType)
+		}
+	}
+
+	var unknown []models.SkillType
+	for _, player := range players {
+		for skillType := range player.Skills {
+			if _, known := models.SkillNames[skillType]; !known && !containsSkill(unknown, skillType) {
+				unknown = append(unknown, skillType)
+			}
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+	return append(skills, unknown...)
+}
+
+// containsSkill reports whether skills contains skillType
+func containsSkill(skills []models.SkillType, skillType models.SkillType) bool {
+	for _, s := range skills {
+		if s == skillType {
+			return true
+		}
+	}
+	return false
+}
+
+// skillName returns a skill's display name
+func skillName(skillType models.SkillType) string {
+	if name, ok := models.SkillNames[skillType]; ok {
+		return name
+	}
+	return string(skillType)
+}
+
+// equippedName returns the name of the item in a slot, or "-"
+func equippedName(player *models.Player, slot models.EquipmentSlot) string {
+	if player.Equipment == nil {
+		return "-"
+	}
+	if item := player.Equipment.GetSlot(slot); item != nil {
+		return item.Name
+	}
+	return "-"
+}
+
+// activityName returns the current activity's name, or "none"
+func activityName(player *models.Player) string {
+	if player.CurrentActivity == nil {
+		return "none"
+	}
+	return player.CurrentActivity.Name
+}
+
+// formatTime formats a timestamp, or "never" for the zero time
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// clockStatus summarises the save's clock history
+func clockStatus(player *models.Player) string {
+	if player.Clock == nil {
+		return "no history"
+	}
+	status := fmt.Sprintf("%d checkpoints", len(player.Clock.Checkpoints))
+	if err := player.Clock.Verify(); err != nil {
+		status += fmt.Sprintf(", %v", err)
+	}
+	if player.Clock.Flags > 0 {
+		status += fmt.Sprintf(", %d suspicious events", player.Clock.Flags)
+	}
+	return status
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"afk-tui/internal/config"
 	"afk-tui/internal/data"
 	"afk-tui/internal/engine"
 	"afk-tui/internal/models"
 	"afk-tui/internal/ui"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -17,9 +21,9 @@ type GameWrapper struct {
 }
 
 // NewGameWrapper creates a new wrapper
-func NewGameWrapper(player *models.Player, saveManager *data.SaveManager) *GameWrapper {
+func NewGameWrapper(player *models.Player, saveManager *data.SaveManager, settings *config.Settings) *GameWrapper {
 	return &GameWrapper{
-		model: engine.NewModel(player, saveManager),
+		model: engine.NewModel(player, saveManager, settings),
 	}
 }
 
@@ -40,31 +44,152 @@ func (w *GameWrapper) View() string {
 	return ui.View(w.model)
 }
 
+// PickerWrapper wraps the profile picker to provide the View method
+type PickerWrapper struct {
+	picker *engine.ProfilePicker
+}
+
+// Init implements tea.Model
+func (w *PickerWrapper) Init() tea.Cmd {
+	return w.picker.Init()
+}
+
+// Update implements tea.Model
+func (w *PickerWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	newPicker, cmd := w.picker.Update(msg)
+	w.picker = newPicker
+	return w, cmd
+}
+
+// View implements tea.Model
+func (w *PickerWrapper) View() string {
+	return ui.ProfilePickerView(w.picker)
+}
+
+// pickProfile runs the profile picker and returns the chosen profile
+func pickProfile(profiles *data.ProfileManager) (string, error) {
+	wrapper := &PickerWrapper{picker: engine.NewProfilePicker(profiles)}
+	if _, err := tea.NewProgram(wrapper, tea.WithAltScreen()).Run(); err != nil {
+		return "", err
+	}
+	return wrapper.picker.Selected, nil
+}
+
 func main() {
-	// Initialize save manager
-	saveManager := data.NewSaveManager("")
+	if len(os.Args) > 1 && os.Args[1] == "save" {
+		if err := runSaveCommand(os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if err := runReplay(os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	// Try to load existing save
-	var player *models.Player
-	var err error
+	profileName := flag.String("profile", "", "play this profile and skip the profile picker")
+	seed := flag.Int64("seed", 0, "seed the game's randomness, to reproduce a session (0 picks a new seed)")
+	dev := flag.Bool("dev", false, "enable the developer console, opened with ':'")
+	record := flag.String("record", "", "record the session to this file, for \"afk-tui replay\"")
+	flag.Parse()
 
-	if saveManager.Exists() {
-		player, err = saveManager.Load()
+	// Settings are optional: a broken file falls back to the defaults
+	settings, err := config.Load()
+	if err != nil {
+		fmt.Printf("Warning: %v (using default settings)\n", err)
+	}
+
+	// Older versions saved into the launch directory; move those saves
+	// into the data directory once
+	profiles, err := openProfiles(os.Stdout)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	name := *profileName
+	if name == "" {
+		var err error
+		name, err = pickProfile(profiles)
 		if err != nil {
-			fmt.Printf("Warning: Failed to load save: %v\n", err)
-			fmt.Println("Starting new game...")
-			player = models.NewPlayer("Adventurer")
-		} else {
-			fmt.Println("Loaded existing save!")
+			fmt.Printf("Error running profile picker: %v\n", err)
+			os.Exit(1)
 		}
-	} else {
+		if name == "" {
+			return
+		}
+	} else if err := data.ValidateProfileName(name); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize save manager
+	saveManager := profiles.SaveManager(name)
+	if err := os.MkdirAll(filepath.Dir(saveManager.SavePath), 0755); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Try to load existing save, falling back to backups if it is damaged
+	player, report, err := saveManager.LoadWithRecovery()
+	if errors.Is(err, data.ErrSaveTooNew) {
+		// Never overwrite a save we can't read with a fresh game
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("Please update AFK-TUI to continue with this save.")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch {
+	case player != nil && report == nil:
+		fmt.Println("Loaded existing save!")
+	case player != nil:
+		fmt.Println("Save was damaged - recovered from backup.")
+	case report != nil:
+		fmt.Println("Save was damaged and no backup could be loaded.")
+		player = models.NewPlayer(name)
+	default:
 		fmt.Println("Welcome to AFK-TUI!")
 		fmt.Println("Starting new game...")
-		player = models.NewPlayer("Adventurer")
+		player = models.NewPlayer(name)
 	}
 
 	// Create game wrapper
-	game := NewGameWrapper(player, saveManager)
+	game := NewGameWrapper(player, saveManager, settings)
+	if *seed != 0 {
+		game.model.SetSeed(*seed)
+	}
+	game.model.DevMode = *dev
+	if report != nil {
+		// Show what happened, and ask before replacing a lost save
+		game.model.ShowRecoveryReport(report)
+	}
+
+	var recorder *engine.Recorder
+	if *record != "" {
+		if report != nil {
+			// The recovery screen can't be set up again in a replay
+			fmt.Println("Warning: not recording, the save needed recovery")
+		} else {
+			f, err := os.Create(*record)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			if recorder, err = engine.StartRecording(f, game.model, settings); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
 
 	// Configure Bubble Tea program
 	p := tea.NewProgram(
@@ -79,8 +204,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	// The recording ends with the player as the session left it
+	if recorder != nil {
+		if err := recorder.Finish(game.model.Player); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			fmt.Printf("\nSession recorded to %s\n", *record)
+		}
+	}
+
 	// Save on exit
-	if err := saveManager.Save(player); err != nil {
+	if !game.model.SaveOnExit {
+		fmt.Println("\nExited without saving.")
+		return
+	}
+	if err := game.model.Save(); err != nil {
 		fmt.Printf("Error saving game: %v\n", err)
 	} else {
 		fmt.Println("\nGame saved successfully!")
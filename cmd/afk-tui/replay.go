@@ -0,0 +1,175 @@
+package main
+
+import (
+	"afk-tui/internal/data"
+	"afk-tui/internal/engine"
+	"afk-tui/internal/models"
+	"afk-tui/internal/ui"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const replayUsage = `Usage:
+  afk-tui replay [--tui] [--speed n] [--out save.json] <recording>
+
+Record a session with "afk-tui --record <recording>".`
+
+// errReplayDiverged is returned when a replay ends with a different player
+// than the recording
+var errReplayDiverged = errors.New("replay does not match the recording")
+
+// runReplay runs "afk-tui replay ...": plays a recording back and checks
+// it ends with the same player
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	tui := fs.Bool("tui", false, "watch the replay in the game's interface instead of running it headless")
+	speed := fs.Float64("speed", 1, "playback speed with --tui, e.g. 10 for ten times faster")
+	out := fs.String("out", "", "write the final player to this save file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("replay needs a recording\n%s", replayUsage)
+	}
+	if *speed <= 0 {
+		return fmt.Errorf("--speed must be more than 0")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	replay, err := engine.LoadReplay(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	// Saves made during the replay must never touch a real profile
+	saveDir, err := os.MkdirTemp("", "afk-tui-replay-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(saveDir)
+
+	model, err := replay.NewModel(data.NewSaveManager(saveDir))
+	if err != nil {
+		return err
+	}
+	if *tui {
+		wrapper := &ReplayWrapper{model: model, replay: replay, speed: *speed}
+		if _, err := tea.NewProgram(wrapper, tea.WithAltScreen()).Run(); err != nil {
+			return err
+		}
+		model = wrapper.model
+		if wrapper.next < len(replay.Msgs) {
+			fmt.Printf("Replay stopped after %d of %d messages.\n", wrapper.next, len(replay.Msgs))
+			return nil
+		}
+	} else {
+		model = replay.Run(model)
+	}
+
+	fmt.Printf("Replayed %d messages (%s of play, seed %d)\n",
+		len(replay.Msgs), models.FormatDuration(replay.Length), replay.Header.Seed)
+	if *out != "" {
+		encoded, err := data.EncodePlayer(model.Player)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*out, encoded, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Final player written to %s\n", *out)
+	}
+
+	if replay.Final == nil {
+		fmt.Println("The recording was cut short, so there is no final player to compare.")
+		return nil
+	}
+	same, err := engine.SamePlayer(replay.Final, model.Player)
+	if err != nil {
+		return err
+	}
+	if !same {
+		fmt.Println("\nRecorded -> replayed")
+		printDiff(os.Stdout, replay.Final, model.Player)
+		return errReplayDiverged
+	}
+	fmt.Println("Final player matches the recording.")
+	return nil
+}
+
+// replayStepMsg delivers the next recorded message
+type replayStepMsg struct{}
+
+// ReplayWrapper plays a recording in the game's interface. Only recorded
+// messages reach the model: its own ticks and the player's keys are dropped,
+// apart from q and Ctrl+C, which stop the replay.
+type ReplayWrapper struct {
+	model  *engine.Model
+	replay *engine.Replay
+	speed  float64
+	next   int // Index of the next recorded message
+}
+
+// Init implements tea.Model
+func (w *ReplayWrapper) Init() tea.Cmd {
+	return tea.Batch(w.model.Init(), w.scheduleNext())
+}
+
+// Update implements tea.Model
+func (w *ReplayWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case replayStepMsg:
+		if w.next >= len(w.replay.Msgs) {
+			return w, nil
+		}
+		newModel, cmd := w.model.Update(w.replay.Msgs[w.next].Msg())
+		w.model = newModel
+		w.next++
+		return w, tea.Batch(cmd, w.scheduleNext())
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return w, tea.Quit
+		}
+		return w, nil
+
+	case tea.WindowSizeMsg, engine.HideMessageMsg:
+		newModel, cmd := w.model.Update(msg)
+		w.model = newModel
+		return w, cmd
+	}
+	return w, nil
+}
+
+// scheduleNext waits until the next recorded message is due
+func (w *ReplayWrapper) scheduleNext() tea.Cmd {
+	if w.next >= len(w.replay.Msgs) {
+		return nil
+	}
+	delay := w.replay.Msgs[w.next].At
+	if w.next > 0 {
+		delay -= w.replay.Msgs[w.next-1].At
+	}
+	delay = time.Duration(float64(delay) / w.speed)
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return replayStepMsg{}
+	})
+}
+
+// View implements tea.Model
+func (w *ReplayWrapper) View() string {
+	status := fmt.Sprintf(" ▶ Replay %d/%d  [q] Stop", w.next, len(w.replay.Msgs))
+	if w.next >= len(w.replay.Msgs) {
+		status = " ■ Replay finished  [q] Quit"
+	}
+	return ui.View(w.model) + "\n" + status
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const saveUsage = `Usage:
+  afk-tui save export [--profile name]            print a save code
+  afk-tui save import [--profile name] [--force] [code]
+                                                  import a code (read from stdin if omitted)
+  afk-tui save inspect [--profile name] [path]    summarise a save file
+  afk-tui save diff <a> <b>                       show what changed between two saves`
+
+// runSaveCommand runs "afk-tui save ...". Output meant for piping goes to
+// stdout, everything else to stderr.
+func runSaveCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing save command\n%s", saveUsage)
+	}
+
+	switch args[0] {
+	case "export":
+		return runSaveExport(args[1:])
+	case "import":
+		return runSaveImport(args[1:])
+	case "inspect":
+		return runSaveInspect(args[1:])
+	case "diff":
+		return runSaveDiff(args[1:])
+	default:
+		return fmt.Errorf("unknown save command %q\n%s", args[0], saveUsage)
+	}
+}
+
+// runSaveExport prints the save code of a profile
+func runSaveExport(args []string) error {
+	fs := flag.NewFlagSet("save export", flag.ContinueOnError)
+	profileName := fs.String("profile", data.DefaultProfile, "profile to export")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	profiles, err := openProfiles(os.Stderr)
+	if err != nil {
+		return err
+	}
+	if !profiles.Exists(*profileName) {
+		return fmt.Errorf("profile %q not found", *profileName)
+	}
+
+	player, err := data.LoadFile(profiles.SaveManager(*profileName).SavePath)
+	if err != nil {
+		return err
+	}
+	code, err := data.ExportCode(player)
+	if err != nil {
+		return err
+	}
+	fmt.Println(code)
+	return nil
+}
+
+// runSaveImport validates a save code and writes it to a profile
+func runSaveImport(args []string) error {
+	fs := flag.NewFlagSet("save import", flag.ContinueOnError)
+	profileName := fs.String("profile", data.DefaultProfile, "profile to import into")
+	force := fs.Bool("force", false, "replace an existing profile (its current save is kept as a backup)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := data.ValidateProfileName(*profileName); err != nil {
+		return err
+	}
+
+	code := fs.Arg(0)
+	if code == "" || code == "-" {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read code: %w", err)
+		}
+		code = string(input)
+	}
+
+	// Validate everything before touching the profile
+	player, err := data.ImportCode(code)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := openProfiles(os.Stderr)
+	if err != nil {
+		return err
+	}
+	if profiles.Exists(*profileName) && !*force {
+		return fmt.Errorf("profile %q already exists; use --force to replace it", *profileName)
+	}
+
+	saveManager := profiles.SaveManager(*profileName)
+	if err := os.MkdirAll(filepath.Dir(saveManager.SavePath), 0755); err != nil {
+		return err
+	}
+	player.ActivityLog.AddEntry(models.LogTypeSystem,
+		fmt.Sprintf("Imported %s from a save code", player.Name),
+		map[string]interface{}{"total_level": player.GetTotalLevel()})
+	if err := saveManager.Save(player); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Imported %s into profile %q\n", player.String(), *profileName)
+	return nil
+}
+
+// openProfiles opens the profile manager for the data directory, first
+// moving in any saves an older version left in the launch directory
+func openProfiles(out io.Writer) (*data.ProfileManager, error) {
+	profiles := data.NewProfileManager("")
+	moved, err := profiles.AdoptLegacySave(".")
+	for _, what := range moved {
+		fmt.Fprintf(out, "Moved %s into %s\n", what, profiles.SaveDir)
+	}
+	return profiles, err
+}
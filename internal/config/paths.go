@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// AppName is the folder name used under the XDG base directories
+const AppName = "afk-tui"
+
+// DataDir returns $XDG_DATA_HOME/afk-tui, defaulting to ~/.local/share/afk-tui
+func DataDir() (string, error) {
+	return xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// ConfigDir returns $XDG_CONFIG_HOME/afk-tui, defaulting to ~/.config/afk-tui
+func ConfigDir() (string, error) {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// xdgDir resolves an XDG base directory. Relative values are ignored, as
+// the XDG Base Directory spec requires.
+func xdgDir(envVar, homeFallback string) (string, error) {
+	if dir := os.Getenv(envVar); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, AppName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find home directory for %s: %w", envVar, err)
+	}
+	return filepath.Join(home, homeFallback, AppName), nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SettingsFile is the settings file name inside ConfigDir
+const SettingsFile = "settings.json"
+
+// Duration is a time.Duration written as a string like "1s" or "24h"
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"1s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Settings are the player's preferences, loaded before the game starts
+type Settings struct {
+	TickRate         Duration   `json:"tick_rate"`
+	OfflineCap       Duration   `json:"offline_cap"`
+	AutosaveInterval Duration   `json:"autosave_interval"` // "0s" disables autosave
+	UI               UISettings `json:"ui"`
+}
+
+// UISettings are display preferences
+type UISettings struct {
+	LogEntriesPerPage int  `json:"log_entries_per_page"`
+	Animations        bool `json:"animations"`
+}
+
+// Default returns the built-in settings
+func Default() *Settings {
+	return &Settings{
+		TickRate:         Duration(time.Second),
+		OfflineCap:       Duration(24 * time.Hour),
+		AutosaveInterval: Duration(time.Minute),
+		UI: UISettings{
+			LogEntriesPerPage: 20,
+			Animations:        true,
+		},
+	}
+}
+
+// Validate checks that settings are usable
+func (s *Settings) Validate() error {
+	if time.Duration(s.TickRate) < 10*time.Millisecond {
+		return fmt.Errorf("tick_rate must be at least 10ms")
+	}
+	if s.OfflineCap < 0 {
+		return fmt.Errorf("offline_cap can't be negative")
+	}
+	if s.AutosaveInterval < 0 {
+		return fmt.Errorf("autosave_interval can't be negative")
+	}
+	if s.UI.LogEntriesPerPage < 1 {
+		return fmt.Errorf("ui.log_entries_per_page must be at least 1")
+	}
+	return nil
+}
+
+// SettingsPath returns the settings file location
+func SettingsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SettingsFile), nil
+}
+
+// Load reads the settings file, writing the defaults on first run so they
+// can be edited. Missing fields keep their default values. On error the
+// defaults are returned along with the error.
+func Load() (*Settings, error) {
+	path, err := SettingsPath()
+	if err != nil {
+		return Default(), err
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads settings from a specific file
+func LoadFile(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		settings := Default()
+		return settings, settings.Save(path)
+	}
+	if err != nil {
+		return Default(), fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	settings := Default()
+	if err := json.Unmarshal(data, settings); err != nil {
+		return Default(), fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := settings.Validate(); err != nil {
+		return Default(), fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// Save writes settings to path
+func (s *Settings) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
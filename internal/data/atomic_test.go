@@ -0,0 +1,125 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"afk-tui/internal/models"
+)
+
+// dirNames lists the files in a folder
+func dirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // Written first, if set
+	}{
+		{name: "new file"},
+		{name: "replaces an existing file", existing: "old save"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "save.json")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := writeFileAtomic(path, []byte("new save"), 0644); err != nil {
+				t.Fatalf("writeFileAtomic: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil || string(data) != "new save" {
+				t.Fatalf("file holds %q (%v), want the new save", data, err)
+			}
+			if info, _ := os.Stat(path); info.Mode().Perm() != 0644 {
+				t.Errorf("permissions %v, want 0644", info.Mode().Perm())
+			}
+			if names := dirNames(t, dir); len(names) != 1 {
+				t.Errorf("folder holds %v, want only save.json", names)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomicCleansUpOnError(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string) string // Returns the path to write
+	}{
+		{
+			name: "folder missing",
+			setup: func(t *testing.T, dir string) string {
+				return filepath.Join(dir, "missing", "save.json")
+			},
+		},
+		{
+			name: "rename fails",
+			setup: func(t *testing.T, dir string) string {
+				// A folder in the way can't be replaced by a file
+				path := filepath.Join(dir, "save.json")
+				if err := os.MkdirAll(filepath.Join(path, "keep"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				return path
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := tt.setup(t, dir)
+			before := dirNames(t, dir)
+
+			if err := writeFileAtomic(path, []byte("new save"), 0644); err == nil {
+				t.Fatal("writeFileAtomic succeeded")
+			}
+			if after := dirNames(t, dir); len(after) != len(before) {
+				t.Errorf("folder holds %v after the failed write, want %v", after, before)
+			}
+		})
+	}
+}
+
+func TestSaveKeepsPreviousAsBackup(t *testing.T) {
+	sm := NewSaveManager(t.TempDir())
+	sm.BackupTiers = nil
+	player := models.NewPlayer("Test")
+
+	for gold := int64(1); gold <= 3; gold++ {
+		player.Gold = gold
+		if err := sm.Save(player); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	saved, err := sm.Load()
+	if err != nil || saved.Gold != 3 {
+		t.Fatalf("save holds %v (%v), want the last save with 3 gold", saved, err)
+	}
+	backup, err := LoadFile(sm.BackupPath())
+	if err != nil || backup.Gold != 2 {
+		t.Fatalf("backup holds %v (%v), want the save before it with 2 gold", backup, err)
+	}
+	if names := dirNames(t, filepath.Dir(sm.SavePath)); len(names) != 2 {
+		t.Errorf("save folder holds %v, want only the save and its backup", names)
+	}
+}
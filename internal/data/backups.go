@@ -0,0 +1,137 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the timestamp used in rotated backup file names
+const backupTimeFormat = "20060102-150405"
+
+// BackupTier keeps timestamped backups taken at most once per Interval
+type BackupTier struct {
+	Name     string
+	Interval time.Duration
+	Keep     int
+}
+
+// DefaultBackupTiers keeps a day of hourly backups and a week of daily ones
+var DefaultBackupTiers = []BackupTier{
+	{Name: "hourly", Interval: time.Hour, Keep: 24},
+	{Name: "daily", Interval: 24 * time.Hour, Keep: 7},
+}
+
+// BackupInfo describes one backup on disk
+type BackupInfo struct {
+	Path string
+	Tier string // "previous" for the single backup of the last save
+	Time time.Time
+}
+
+// BackupDir returns the folder holding rotated backups
+func (sm *SaveManager) BackupDir() string {
+	return filepath.Join(filepath.Dir(sm.SavePath), "backups")
+}
+
+// backupPrefix is the file name prefix shared by all rotated backups
+func (sm *SaveManager) backupPrefix() string {
+	return strings.TrimSuffix(filepath.Base(sm.SavePath), ".json") + "."
+}
+
+// rotateBackups writes a new backup for each tier that is due and
+// prunes each tier down to its Keep count
+func (sm *SaveManager) rotateBackups(data []byte, now time.Time) error {
+	if len(sm.BackupTiers) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(sm.BackupDir(), 0755); err != nil {
+		return err
+	}
+
+	existing := sm.rotatedBackups()
+	for _, tier := range sm.BackupTiers {
+		if tier.Keep <= 0 {
+			continue
+		}
+
+		var inTier []BackupInfo
+		for _, backup := range existing {
+			if backup.Tier == tier.Name {
+				inTier = append(inTier, backup)
+			}
+		}
+
+		// Newest first, so inTier[0] is the latest backup
+		if len(inTier) == 0 || now.Sub(inTier[0].Time) >= tier.Interval {
+			name := fmt.Sprintf("%s%s.%s.json", sm.backupPrefix(), tier.Name, now.Format(backupTimeFormat))
+			path := filepath.Join(sm.BackupDir(), name)
+			if err := writeFileAtomic(path, data, 0644); err != nil {
+				return err
+			}
+			inTier = append([]BackupInfo{{Path: path, Tier: tier.Name, Time: now}}, inTier...)
+		}
+
+		for _, old := range inTier[min(len(inTier), tier.Keep):] {
+			if err := os.Remove(old.Path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rotatedBackups lists the timestamped backups, newest first
+func (sm *SaveManager) rotatedBackups() []BackupInfo {
+	entries, err := os.ReadDir(sm.BackupDir())
+	if err != nil {
+		return nil
+	}
+
+	prefix := sm.backupPrefix()
+	var backups []BackupInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		// <prefix><tier>.<timestamp>.json
+		parts := strings.SplitN(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".json"), ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		stamp, err := time.ParseInLocation(backupTimeFormat, parts[1], time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, BackupInfo{
+			Path: filepath.Join(sm.BackupDir(), name),
+			Tier: parts[0],
+			Time: stamp,
+		})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups
+}
+
+// ListBackups returns every backup of this save, newest first,
+// starting with the backup of the previous save
+func (sm *SaveManager) ListBackups() []BackupInfo {
+	var backups []BackupInfo
+	if info, err := os.Stat(sm.BackupPath()); err == nil {
+		backups = append(backups, BackupInfo{
+			Path: sm.BackupPath(),
+			Tier: "previous",
+			Time: info.ModTime(),
+		})
+	}
+	return append(backups, sm.rotatedBackups()...)
+}
@@ -0,0 +1,108 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotateBackups(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local)
+	tiers := []BackupTier{
+		{Name: "hourly", Interval: time.Hour, Keep: 3},
+		{Name: "daily", Interval: 24 * time.Hour, Keep: 2},
+		{Name: "off", Interval: time.Minute, Keep: 0},
+	}
+
+	tests := []struct {
+		name  string
+		every time.Duration // Time between saves
+		saves int
+		want  map[string][]time.Duration // Backups kept per tier, newest first, as time since start
+	}{
+		{
+			name:  "one save",
+			every: time.Minute,
+			saves: 1,
+			want: map[string][]time.Duration{
+				"hourly": {0},
+				"daily":  {0},
+			},
+		},
+		{
+			name:  "saves more often than the interval",
+			every: 20 * time.Minute,
+			saves: 10, // Up to 3h
+			want: map[string][]time.Duration{
+				"hourly": {3 * time.Hour, 2 * time.Hour, time.Hour},
+				"daily":  {0},
+			},
+		},
+		{
+			name:  "oldest pruned in every tier",
+			every: 12 * time.Hour,
+			saves: 7, // Up to 3 days
+			want: map[string][]time.Duration{
+				"hourly": {72 * time.Hour, 60 * time.Hour, 48 * time.Hour},
+				"daily":  {72 * time.Hour, 48 * time.Hour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewSaveManager(t.TempDir())
+			sm.BackupTiers = tiers
+
+			for i := 0; i < tt.saves; i++ {
+				if err := sm.rotateBackups([]byte(savedPlayer(t, int64(i))), start.Add(time.Duration(i)*tt.every)); err != nil {
+					t.Fatalf("rotateBackups: %v", err)
+				}
+			}
+
+			got := make(map[string][]time.Duration)
+			for _, backup := range sm.rotatedBackups() {
+				got[backup.Tier] = append(got[backup.Tier], backup.Time.Sub(start))
+			}
+			for tier, want := range tt.want {
+				if len(got[tier]) != len(want) {
+					t.Errorf("%s backups at %v, want %v", tier, got[tier], want)
+					continue
+				}
+				for i := range want {
+					if got[tier][i] != want[i] {
+						t.Errorf("%s backups at %v, want %v", tier, got[tier], want)
+						break
+					}
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("backups in tiers %v, want only %d tiers", got, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestListBackupsNewestFirst(t *testing.T) {
+	sm := NewSaveManager(t.TempDir())
+	player := savedPlayer(t, 1)
+
+	if err := writeFileAtomic(sm.SavePath, []byte(player), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.backupCurrent(); err != nil {
+		t.Fatalf("backupCurrent: %v", err)
+	}
+	older := writeRotatedBackup(t, sm, 2*time.Hour, player)
+	newer := writeRotatedBackup(t, sm, time.Hour, player)
+
+	backups := sm.ListBackups()
+	if len(backups) != 3 {
+		t.Fatalf("listed %d backups, want 3", len(backups))
+	}
+	if backups[0].Tier != "previous" || backups[0].Path != sm.BackupPath() {
+		t.Errorf("first backup is %+v, want the previous save", backups[0])
+	}
+	if backups[1].Path != newer || backups[2].Path != older {
+		t.Errorf("rotated backups listed as %s, %s, want the newest first", backups[1].Path, backups[2].Path)
+	}
+}
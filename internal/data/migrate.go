@@ -0,0 +1,204 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"afk-tui/internal/models"
+)
+
+// CurrentSchemaVersion is the save schema written by this build.
+// Bump it together with a new migration whenever the save layout changes.
+const CurrentSchemaVersion = 3
+
+// ErrSaveTooNew is returned for saves written by a newer version of the game
+var ErrSaveTooNew = errors.New("save was written by a newer version of AFK-TUI")
+
+// SaveDocument is a save as raw JSON, keyed by top-level field name.
+// Migrations work on this form so they never depend on the current Player struct.
+type SaveDocument map[string]json.RawMessage
+
+// Migration upgrades a save document from version From to From+1
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(doc SaveDocument) error
+}
+
+// migrations is the registry, keyed by the version each migration upgrades from
+var migrations = map[int]Migration{}
+
+// RegisterMigration adds a migration to the registry.
+// Registering two migrations for the same version is a programming error.
+func RegisterMigration(m Migration) {
+	if _, exists := migrations[m.From]; exists {
+		panic(fmt.Sprintf("duplicate save migration from version %d", m.From))
+	}
+	migrations[m.From] = m
+}
+
+func init() {
+	RegisterMigration(Migration{
+		From:        0,
+		Description: "Add activity log, combat stats and attributes",
+		Apply:       migrateV0ToV1,
+	})
+	RegisterMigration(Migration{
+		From:        1,
+		Description: "Refresh unlocked perks from the perk table",
+		Apply:       migrateV1ToV2,
+	})
+	RegisterMigration(Migration{
+		From:        2,
+		Description: "Add resume state, offline cap upgrades, clock history, bank and overflow policy",
+		Apply:       migrateV2ToV3,
+	})
+}
+
+// saveFile is the on-disk layout: the schema version followed by the player
+type saveFile struct {
+	SchemaVersion int `json:"schema_version"`
+	*models.Player
+}
+
+// encodeSave serializes a player with the current schema version
+func encodeSave(player *models.Player) ([]byte, error) {
+	return json.MarshalIndent(saveFile{
+		SchemaVersion: CurrentSchemaVersion,
+		Player:        player,
+	}, "", "  ")
+}
+
+// SchemaVersion reads the schema version of a save document.
+// Saves written before versioning have no field and count as version 0.
+func (doc SaveDocument) SchemaVersion() (int, error) {
+	raw, ok := doc["schema_version"]
+	if !ok {
+		return 0, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("invalid schema_version: %w", err)
+	}
+	return version, nil
+}
+
+// isMissing reports whether a field is absent or null
+func (doc SaveDocument) isMissing(key string) bool {
+	raw, ok := doc[key]
+	return !ok || string(raw) == "null"
+}
+
+// set marshals value into the document under key
+func (doc SaveDocument) set(key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	doc[key] = raw
+	return nil
+}
+
+// MigrateSave upgrades raw save JSON to CurrentSchemaVersion.
+// It returns the upgraded JSON and the version the save started at.
+func MigrateSave(data []byte) ([]byte, int, error) {
+	var doc SaveDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse save: %w", err)
+	}
+
+	version, err := doc.SchemaVersion()
+	if err != nil {
+		return nil, 0, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, version, fmt.Errorf("%w (save is version %d, this build supports up to %d)",
+			ErrSaveTooNew, version, CurrentSchemaVersion)
+	}
+	if version == CurrentSchemaVersion {
+		return data, version, nil
+	}
+
+	for v := version; v < CurrentSchemaVersion; v++ {
+		migration, ok := migrations[v]
+		if !ok {
+			return nil, version, fmt.Errorf("no migration registered from schema version %d", v)
+		}
+		if err := migration.Apply(doc); err != nil {
+			return nil, version, fmt.Errorf("migration %d→%d (%s) failed: %w", v, v+1, migration.Description, err)
+		}
+		if err := doc.set("schema_version", v+1); err != nil {
+			return nil, version, err
+		}
+	}
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, version, fmt.Errorf("failed to encode migrated save: %w", err)
+	}
+	return migrated, version, nil
+}
+
+// migrateV0ToV1 fills in sections that older saves were written without
+func migrateV0ToV1(doc SaveDocument) error {
+	if doc.isMissing("activity_log") {
+		log := models.NewActivityLog()
+		log.AddEntry(models.LogTypeSystem, "Save loaded - Activity log initialized", nil)
+		if err := doc.set("activity_log", log); err != nil {
+			return err
+		}
+	}
+
+	if doc.isMissing("combat_stats") {
+		if err := doc.set("combat_stats", models.NewCombatStats()); err != nil {
+			return err
+		}
+	}
+
+	if doc.isMissing("attributes") {
+		if err := doc.set("attributes", models.NewCharacterAttributes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateV1ToV2 replaces saved copies of perks with the current definitions.
+// Triple drop perks used to be saved with the double drop effect.
+func migrateV1ToV2(doc SaveDocument) error {
+	if doc.isMissing("unlocked_perks") {
+		return nil
+	}
+
+	var perks []json.RawMessage
+	if err := json.Unmarshal(doc["unlocked_perks"], &perks); err != nil {
+		return fmt.Errorf("invalid unlocked_perks: %w", err)
+	}
+	for i, raw := range perks {
+		var saved struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &saved); err != nil {
+			return fmt.Errorf("invalid unlocked perk: %w", err)
+		}
+		// Perks no longer in the table are kept as saved
+		if perk, ok := models.GetPerkByID(saved.ID); ok {
+			current, err := json.Marshal(perk)
+			if err != nil {
+				return fmt.Errorf("failed to encode perk %s: %w", perk.ID, err)
+			}
+			perks[i] = current
+		}
+	}
+	return doc.set("unlocked_perks", perks)
+}
+
+// migrateV2ToV3 has nothing to convert: the fields added in version 3 are
+// optional and empty means their default. The version bump is what counts,
+// as it stops older builds from loading these saves and dropping the new
+// fields, such as banked items, when they save over them.
+func migrateV2ToV3(doc SaveDocument) error {
+	return nil
+}
@@ -0,0 +1,238 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"afk-tui/internal/models"
+)
+
+// migrateTo runs a single registered migration on a save document
+func migrateTo(t *testing.T, from int, save string) SaveDocument {
+	t.Helper()
+	var doc SaveDocument
+	if err := json.Unmarshal([]byte(save), &doc); err != nil {
+		t.Fatalf("invalid test save: %v", err)
+	}
+	if err := migrations[from].Apply(doc); err != nil {
+		t.Fatalf("migration from %d failed: %v", from, err)
+	}
+	return doc
+}
+
+func TestMigrateV0ToV1(t *testing.T) {
+	tests := []struct {
+		name    string
+		save    string
+		added   []string // Sections the migration must fill in
+		kept    string   // A section that must be left as saved
+		keptRaw string
+	}{
+		{
+			name:  "missing everything",
+			save:  `{"name":"Old"}`,
+			added: []string{"activity_log", "combat_stats", "attributes"},
+		},
+		{
+			name:    "missing activity log",
+			save:    `{"name":"Old","combat_stats":{"strength":7},"attributes":null}`,
+			added:   []string{"activity_log", "attributes"},
+			kept:    "combat_stats",
+			keptRaw: `{"strength":7}`,
+		},
+		{
+			name:    "missing combat stats",
+			save:    `{"name":"Old","activity_log":{"entries":[]}}`,
+			added:   []string{"combat_stats", "attributes"},
+			kept:    "activity_log",
+			keptRaw: `{"entries":[]}`,
+		},
+		{
+			name:    "missing attributes",
+			save:    `{"name":"Old","activity_log":null,"combat_stats":{"strength":7}}`,
+			added:   []string{"activity_log", "attributes"},
+			kept:    "combat_stats",
+			keptRaw: `{"strength":7}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := migrateTo(t, 0, tt.save)
+			for _, key := range tt.added {
+				if doc.isMissing(key) {
+					t.Errorf("%s was not added", key)
+				}
+			}
+			if tt.kept != "" && string(doc[tt.kept]) != tt.keptRaw {
+				t.Errorf("%s = %s, want it kept as %s", tt.kept, doc[tt.kept], tt.keptRaw)
+			}
+		})
+	}
+}
+
+func TestMigrateV1ToV2(t *testing.T) {
+	current, ok := models.GetPerkByID("wc_triple")
+	if !ok {
+		t.Fatal("wc_triple is not in the perk table")
+	}
+
+	tests := []struct {
+		name  string
+		perks string
+		want  []models.Perk
+	}{
+		{
+			name:  "no perks",
+			perks: `null`,
+		},
+		{
+			name:  "triple drop saved as double drop",
+			perks: `[{"id":"wc_triple","effect":"double_drop","value":0.1}]`,
+			want:  []models.Perk{current},
+		},
+		{
+			name:  "perk no longer in the table",
+			perks: `[{"id":"retired_perk","effect":"xp_boost","value":0.5}]`,
+			want:  []models.Perk{{ID: "retired_perk", Effect: models.PerkEffectXPBoost, Value: 0.5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := migrateTo(t, 1, `{"unlocked_perks":`+tt.perks+`}`)
+			var got []models.Perk
+			if err := json.Unmarshal(doc["unlocked_perks"], &got); err != nil {
+				t.Fatalf("invalid unlocked_perks: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d perks, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("perk %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateV2ToV3(t *testing.T) {
+	saves := []string{
+		`{"name":"Old"}`,
+		`{"name":"Old","bank":{"items":[{"id":"logs","quantity":5}]},"overflow_policy":"bank","offline_cap_upgrades":2,` +
+			`"clock":{"flags":1},"resume":{"screen":"skills"}}`,
+	}
+
+	for _, save := range saves {
+		var want SaveDocument
+		if err := json.Unmarshal([]byte(save), &want); err != nil {
+			t.Fatalf("invalid test save: %v", err)
+		}
+		doc := migrateTo(t, 2, save)
+		if len(doc) != len(want) {
+			t.Errorf("%s: migrated to %d fields, want %d", save, len(doc), len(want))
+		}
+		for key, raw := range want {
+			if string(doc[key]) != string(raw) {
+				t.Errorf("%s: %s = %s, want it kept as %s", save, key, doc[key], raw)
+			}
+		}
+	}
+}
+
+func TestMigrateSave(t *testing.T) {
+	tests := []struct {
+		name        string
+		save        string
+		fromVersion int
+		err         error
+	}{
+		{
+			name: "pre-versioned save",
+			save: `{"name":"Old","gold":12,"unlocked_perks":[{"id":"mining_triple","effect":"double_drop"}]}`,
+		},
+		{
+			name:        "version 1",
+			save:        `{"schema_version":1,"name":"Old","gold":12}`,
+			fromVersion: 1,
+		},
+		{
+			name:        "version 2",
+			save:        `{"schema_version":2,"name":"Old","gold":12,"bank":{"items":[{"id":"logs","quantity":5}]}}`,
+			fromVersion: 2,
+		},
+		{
+			name:        "current version",
+			save:        `{"schema_version":3,"name":"Old","gold":12}`,
+			fromVersion: CurrentSchemaVersion,
+		},
+		{
+			name:        "newer version",
+			save:        `{"schema_version":99,"name":"Old"}`,
+			fromVersion: 99,
+			err:         ErrSaveTooNew,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrated, from, err := MigrateSave([]byte(tt.save))
+			if from != tt.fromVersion {
+				t.Errorf("started at version %d, want %d", from, tt.fromVersion)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MigrateSave: %v", err)
+			}
+
+			var doc SaveDocument
+			if err := json.Unmarshal(migrated, &doc); err != nil {
+				t.Fatalf("invalid migrated save: %v", err)
+			}
+			if version, _ := doc.SchemaVersion(); version != CurrentSchemaVersion {
+				t.Errorf("schema_version = %d, want %d", version, CurrentSchemaVersion)
+			}
+
+			var player models.Player
+			if err := json.Unmarshal(migrated, &player); err != nil {
+				t.Fatalf("migrated save is not a player: %v", err)
+			}
+			if player.Name != "Old" || player.Gold != 12 {
+				t.Errorf("player = %q with %d gold, want \"Old\" with 12", player.Name, player.Gold)
+			}
+			if tt.fromVersion == 0 {
+				if player.ActivityLog == nil || player.CombatStats == nil || player.Attributes == nil {
+					t.Error("v0 sections were not filled in")
+				}
+				if len(player.UnlockedPerks) != 1 || player.UnlockedPerks[0].Effect != models.PerkEffectTripleDrop {
+					t.Errorf("unlocked perks = %+v, want the current mining_triple", player.UnlockedPerks)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateSaveMissingStep(t *testing.T) {
+	removed := migrations[1]
+	delete(migrations, 1)
+	t.Cleanup(func() { migrations[1] = removed })
+
+	_, from, err := MigrateSave([]byte(`{"name":"Old"}`))
+	if err == nil {
+		t.Fatal("MigrateSave succeeded without a migration from version 1")
+	}
+	if from != 0 {
+		t.Errorf("started at version %d, want 0", from)
+	}
+	if !strings.Contains(err.Error(), "no migration registered from schema version 1") {
+		t.Errorf("err = %v, want it to name the missing version", err)
+	}
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"afk-tui/internal/game"
+	"afk-tui/internal/models"
+)
+
+// isFighting reports whether the time away goes to combat: a fight was
+// under way on the combat screen, or a monster is selected and no activity
+// is running. Anything else ticks the activity, as live play does between
+// fights.
+func isFighting(player *models.Player) bool {
+	resume := player.Resume
+	if resume == nil || models.Monsters.GetMonster(resume.MonsterID) == nil ||
+		player.CombatStats == nil || player.Attributes == nil {
+		return false
+	}
+	inProgress := resume.Screen == models.ResumeScreenCombat && resume.Combat != nil
+	return inProgress || player.CurrentActivity == nil
+}
+
+// simulateCombat keeps fighting the selected monster for up to totalTicks,
+// picking up the saved fight if there is one and starting a new fight after
+// every kill. A defeat ends the session, like it does in the live game, and
+// so does a full inventory under the stop overflow policy. The fight still
+// under way is saved back to the player's resume state so the game reopens
+// on it.
+func simulateCombat(player *models.Player, result *OfflineResult, totalTicks int, tickRate time.Duration, rng models.RNG) {
+	encounter := game.RestoreEncounter(player.Resume.Combat)
+	if encounter == nil || encounter.Monster.ID != player.Resume.MonsterID {
+		encounter = game.NewCombatEncounter(player.Resume.MonsterID)
+	}
+	monster := encounter.Monster
+
+	result.InCombat = true
+	result.ActivityName = fmt.Sprintf("Fighting %s", monster.Name)
+	result.SkillName = models.SkillNames[models.SkillCombat]
+	result.SkillType = models.SkillCombat
+
+	for result.TicksProcessed < totalTicks {
+		tick := game.TickCombat(player, encounter, rng)
+		result.TicksProcessed++
+
+		if tick.Defeated {
+			result.Deaths++
+			result.StopReason = fmt.Sprintf("Defeated by %s", monster.Name)
+			result.StoppedAfter = time.Duration(result.TicksProcessed) * tickRate
+			player.Resume.MonsterID = ""
+			player.Resume.Combat = nil
+			player.Resume.Screen = models.ResumeScreenSlayerMonsters
+			return
+		}
+		if !tick.Won {
+			continue
+		}
+
+		result.Kills++
+		result.ActionsCompleted++
+		result.XPGained += tick.CombatXP
+		result.SlayerXP += tick.SlayerXP
+		result.SlayerLevels += tick.SlayerLevels
+		result.GoldGained += tick.Gold
+		result.PerksUnlocked = append(result.PerksUnlocked, tick.Perks...)
+		result.addHaul(&tick.Haul)
+
+		if tick.Stop {
+			result.StopReason = "Inventory full"
+			result.StoppedAfter = time.Duration(result.TicksProcessed) * tickRate
+			player.Resume.Combat = nil
+			player.Resume.Screen = models.ResumeScreenSlayerMonsters
+			return
+		}
+
+		encounter = game.NewCombatEncounter(monster.ID)
+	}
+
+	player.Resume.Combat = encounter.Snapshot()
+	player.Resume.Screen = models.ResumeScreenCombat
+}
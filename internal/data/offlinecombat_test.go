@@ -0,0 +1,84 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+func TestOfflineCombatFollowsSelectedMonster(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		activity  string // Running when the game saved, if set
+		resume    *models.ResumeState
+		wantFight bool
+	}{
+		{
+			name:     "no monster selected",
+			activity: "chop_logs",
+			resume:   &models.ResumeState{Screen: "dashboard"},
+		},
+		{
+			name:     "activity running with a monster still selected",
+			activity: "chop_logs",
+			resume:   &models.ResumeState{Screen: "dashboard", SlayerTier: 1, MonsterID: "chicken"},
+		},
+		{
+			name:      "back on the monster list after a kill",
+			resume:    &models.ResumeState{Screen: models.ResumeScreenSlayerMonsters, SlayerTier: 1, MonsterID: "chicken"},
+			wantFight: true,
+		},
+		{
+			name:     "fight in progress with an activity running",
+			activity: "chop_logs",
+			resume: &models.ResumeState{Screen: models.ResumeScreenCombat, SlayerTier: 1, MonsterID: "chicken",
+				Combat: &models.CombatSnapshot{MonsterID: "chicken", MonsterHP: 1}},
+			wantFight: true,
+		},
+		{
+			name: "saved fight against another monster",
+			resume: &models.ResumeState{Screen: models.ResumeScreenCombat, SlayerTier: 1, MonsterID: "chicken",
+				Combat: &models.CombatSnapshot{MonsterID: "cow", MonsterHP: 1}},
+			wantFight: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := awayPlayer("chop_logs", 10*time.Minute, now)
+			if tt.activity == "" {
+				player.CurrentActivity = nil
+			}
+			player.Resume = tt.resume
+
+			result := fixedProcessor(now).CalculateOfflineProgress(player)
+
+			if result.InCombat != tt.wantFight {
+				t.Fatalf("InCombat = %v, want %v", result.InCombat, tt.wantFight)
+			}
+			if !tt.wantFight {
+				if result.ActionsCompleted == 0 {
+					t.Error("no logs chopped while away")
+				}
+				if player.Resume.Screen == models.ResumeScreenCombat {
+					t.Error("resumes on the combat screen without a fight")
+				}
+				return
+			}
+			if result.Kills == 0 {
+				t.Error("no chickens killed while away")
+			}
+			if result.Deaths == 0 {
+				if player.Resume.Screen != models.ResumeScreenCombat {
+					t.Errorf("resumes on %q, want the fight", player.Resume.Screen)
+				}
+				if fight := player.Resume.Combat; fight == nil || fight.MonsterID != "chicken" {
+					t.Errorf("saved fight = %+v, want one against the chicken", fight)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// DefaultProfile is the profile an old single-save install is moved into
+const DefaultProfile = "default"
+
+// ProfileManager manages named save profiles inside one save directory.
+// Each profile is a folder holding its own save and backups.
+type ProfileManager struct {
+	SaveDir string
+	Dir     string
+}
+
+// ProfileInfo summarises a profile for the picker
+type ProfileInfo struct {
+	Name       string
+	Player     string
+	TotalLevel int
+	Playtime   time.Duration
+	LastOnline time.Time
+	Err        error // Set when the profile's save can't be read
+}
+
+// NewProfileManager creates a profile manager for a save directory.
+// An empty saveDir uses DefaultSaveDir.
+func NewProfileManager(saveDir string) *ProfileManager {
+	if saveDir == "" {
+		saveDir = DefaultSaveDir()
+	}
+	return &ProfileManager{
+		SaveDir: saveDir,
+		Dir:     filepath.Join(saveDir, "profiles"),
+	}
+}
+
+// ValidateProfileName checks that a name is safe to use as a folder name
+func ValidateProfileName(name string) error {
+	if len(name) == 0 || len(name) > 20 {
+		return fmt.Errorf("profile name must be 1-20 characters")
+	}
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("profile name can't start or end with a space")
+	}
+	for _, r := range name {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit && r != ' ' && r != '-' && r != '_' {
+			return fmt.Errorf("profile name can only use letters, numbers, spaces, - and _")
+		}
+	}
+	return nil
+}
+
+// path returns the folder of a profile
+func (pm *ProfileManager) path(name string) string {
+	return filepath.Join(pm.Dir, name)
+}
+
+// SaveManager returns the save manager for a profile
+func (pm *ProfileManager) SaveManager(name string) *SaveManager {
+	return NewSaveManager(pm.path(name))
+}
+
+// Exists checks if a profile exists. Invalid names never exist, which keeps
+// names like ".." from reaching the filesystem operations below.
+func (pm *ProfileManager) Exists(name string) bool {
+	if ValidateProfileName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(pm.path(name))
+	return err == nil && info.IsDir()
+}
+
+// List returns all profiles, most recently played first
+func (pm *ProfileManager) List() ([]ProfileInfo, error) {
+	entries, err := os.ReadDir(pm.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles []ProfileInfo
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		info := ProfileInfo{Name: entry.Name()}
+		player, err := LoadFile(pm.SaveManager(entry.Name()).SavePath)
+		if err != nil {
+			info.Err = err
+		} else {
+			info.Player = player.Name
+			info.TotalLevel = player.GetTotalLevel()
+			info.Playtime = player.TotalPlaytime
+			info.LastOnline = player.LastOnline
+		}
+		profiles = append(profiles, info)
+	}
+
+	sort.SliceStable(profiles, func(i, j int) bool {
+		return profiles[i].LastOnline.After(profiles[j].LastOnline)
+	})
+	return profiles, nil
+}
+
+// Create makes a new profile with a fresh character of the same name
+func (pm *ProfileManager) Create(name string) (*models.Player, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	if pm.Exists(name) {
+		return nil, fmt.Errorf("profile %q already exists", name)
+	}
+	if err := os.MkdirAll(pm.path(name), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	player := models.NewPlayer(name)
+	if err := pm.SaveManager(name).Save(player); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// Rename renames a profile. The character inside keeps its name.
+func (pm *ProfileManager) Rename(oldName, newName string) error {
+	if err := ValidateProfileName(newName); err != nil {
+		return err
+	}
+	if !pm.Exists(oldName) {
+		return fmt.Errorf("profile %q not found", oldName)
+	}
+	if pm.Exists(newName) {
+		return fmt.Errorf("profile %q already exists", newName)
+	}
+	if err := os.Rename(pm.path(oldName), pm.path(newName)); err != nil {
+		return fmt.Errorf("failed to rename profile: %w", err)
+	}
+	return nil
+}
+
+// Duplicate copies a profile's current save into a new profile
+func (pm *ProfileManager) Duplicate(srcName, dstName string) error {
+	if err := ValidateProfileName(dstName); err != nil {
+		return err
+	}
+	if !pm.Exists(srcName) {
+		return fmt.Errorf("profile %q not found", srcName)
+	}
+	if pm.Exists(dstName) {
+		return fmt.Errorf("profile %q already exists", dstName)
+	}
+	if err := os.MkdirAll(pm.path(dstName), 0755); err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+
+	src := pm.SaveManager(srcName).SavePath
+	if err := copyFileAtomic(src, pm.SaveManager(dstName).SavePath, 0644); err != nil {
+		os.RemoveAll(pm.path(dstName))
+		return fmt.Errorf("failed to copy save: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a profile and all of its saves and backups
+func (pm *ProfileManager) Delete(name string) error {
+	if !pm.Exists(name) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	return os.RemoveAll(pm.path(name))
+}
+
+// AdoptLegacySave moves saves from an older install into this save
+// directory. legacyDir is where older versions wrote their files, usually
+// the folder the game was launched from: a pre-profile save there becomes
+// the default profile, and each profile folder is moved across unless a
+// profile with that name already exists. It returns what it moved.
+func (pm *ProfileManager) AdoptLegacySave(legacyDir string) ([]string, error) {
+	var moved []string
+
+	legacy := NewSaveManager(legacyDir)
+	if legacy.Exists() && !pm.Exists(DefaultProfile) {
+		if err := os.MkdirAll(pm.path(DefaultProfile), 0755); err != nil {
+			return moved, fmt.Errorf("failed to create profile: %w", err)
+		}
+
+		target := pm.SaveManager(DefaultProfile)
+		for _, pair := range [][2]string{
+			{legacy.SavePath, target.SavePath},
+			{legacy.BackupPath(), target.BackupPath()},
+			{legacy.BackupDir(), target.BackupDir()},
+		} {
+			if _, err := os.Stat(pair[0]); os.IsNotExist(err) {
+				continue
+			}
+			if err := movePath(pair[0], pair[1]); err != nil {
+				return moved, fmt.Errorf("failed to move %s: %w", filepath.Base(pair[0]), err)
+			}
+		}
+		moved = append(moved, fmt.Sprintf("%s -> profile %q", legacy.SavePath, DefaultProfile))
+	}
+
+	// Profiles kept next to the binary by earlier versions
+	legacyProfiles := NewProfileManager(legacyDir)
+	if sameDir(legacyProfiles.Dir, pm.Dir) {
+		return moved, nil
+	}
+	entries, err := os.ReadDir(legacyProfiles.Dir)
+	if err != nil {
+		return moved, nil
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || ValidateProfileName(name) != nil || pm.Exists(name) {
+			continue
+		}
+		if err := os.MkdirAll(pm.Dir, 0755); err != nil {
+			return moved, fmt.Errorf("failed to create profiles folder: %w", err)
+		}
+		if err := movePath(legacyProfiles.path(name), pm.path(name)); err != nil {
+			return moved, fmt.Errorf("failed to move profile %q: %w", name, err)
+		}
+		moved = append(moved, fmt.Sprintf("profile %q", name))
+	}
+	os.Remove(legacyProfiles.Dir) // Only succeeds once it is empty
+
+	return moved, nil
+}
+
+// sameDir reports whether two paths name the same folder
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
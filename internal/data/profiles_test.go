@@ -0,0 +1,169 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"default", true},
+		{"Main Character", true},
+		{"alt-2_hc", true},
+		{"12345678901234567890", true},
+		{"", false},
+		{"123456789012345678901", false},
+		{" leading", false},
+		{"trailing ", false},
+		{"..", false},
+		{"a/b", false},
+		{`a\b`, false},
+		{"café", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateProfileName(tt.name); (err == nil) != tt.valid {
+				t.Errorf("ValidateProfileName(%q) = %v, want valid: %v", tt.name, err, tt.valid)
+			}
+		})
+	}
+}
+
+// writeSave saves a fresh player with some gold into a save folder
+func writeSave(t *testing.T, dir string, gold int64) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(NewSaveManager(dir).SavePath, []byte(savedPlayer(t, gold)), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// profileGold returns the gold in a profile's save, or -1 if it can't be read
+func profileGold(pm *ProfileManager, name string) int64 {
+	player, err := pm.SaveManager(name).Load()
+	if err != nil {
+		return -1
+	}
+	return player.Gold
+}
+
+func TestAdoptLegacySave(t *testing.T) {
+	tests := []struct {
+		name           string
+		legacySave     bool     // A pre-profile save in the legacy folder, with 1 gold
+		legacyProfiles []string // Profile folders in the legacy folder, with 3 gold
+		profiles       []string // Profiles already in the save folder, with 2 gold
+
+		wantGold  map[string]int64 // Gold per profile afterwards
+		wantMoved int
+		wantLeft  bool // The legacy save stays where it was
+	}{
+		{
+			name:       "single legacy save",
+			legacySave: true,
+			wantGold:   map[string]int64{DefaultProfile: 1},
+			wantMoved:  1,
+		},
+		{
+			name:       "default profile already exists",
+			legacySave: true,
+			profiles:   []string{DefaultProfile},
+			wantGold:   map[string]int64{DefaultProfile: 2},
+			wantLeft:   true,
+		},
+		{
+			name:           "legacy profiles",
+			legacyProfiles: []string{"alice", "bob"},
+			profiles:       []string{"bob"},
+			wantGold:       map[string]int64{"alice": 3, "bob": 2},
+			wantMoved:      1,
+		},
+		{
+			name:     "nothing to adopt",
+			profiles: []string{"alice"},
+			wantGold: map[string]int64{"alice": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legacyDir := t.TempDir()
+			pm := NewProfileManager(t.TempDir())
+
+			legacy := NewSaveManager(legacyDir)
+			if tt.legacySave {
+				writeSave(t, legacyDir, 1)
+				if err := copyFileAtomic(legacy.SavePath, legacy.BackupPath(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, name := range tt.legacyProfiles {
+				writeSave(t, NewProfileManager(legacyDir).path(name), 3)
+			}
+			for _, name := range tt.profiles {
+				writeSave(t, pm.path(name), 2)
+			}
+
+			moved, err := pm.AdoptLegacySave(legacyDir)
+			if err != nil {
+				t.Fatalf("AdoptLegacySave: %v", err)
+			}
+			if len(moved) != tt.wantMoved {
+				t.Errorf("moved %v, want %d moves", moved, tt.wantMoved)
+			}
+
+			for name, gold := range tt.wantGold {
+				if got := profileGold(pm, name); got != gold {
+					t.Errorf("profile %q has %d gold, want %d", name, got, gold)
+				}
+			}
+			if profiles, _ := pm.List(); len(profiles) != len(tt.wantGold) {
+				t.Errorf("%d profiles, want %d", len(profiles), len(tt.wantGold))
+			}
+
+			if tt.legacySave {
+				if legacy.Exists() != tt.wantLeft {
+					t.Errorf("legacy save left in place: %v, want %v", legacy.Exists(), tt.wantLeft)
+				}
+				if !tt.wantLeft {
+					if _, err := os.Stat(pm.SaveManager(DefaultProfile).BackupPath()); err != nil {
+						t.Errorf("the legacy backup was not moved: %v", err)
+					}
+				}
+			}
+			// A profile whose name is taken stays in the old folder
+			for _, name := range tt.legacyProfiles {
+				_, err := os.Stat(filepath.Join(legacyDir, "profiles", name))
+				if stayed, taken := err == nil, slices.Contains(tt.profiles, name); stayed != taken {
+					t.Errorf("legacy profile %q left in the old folder: %v, want %v", name, stayed, taken)
+				}
+			}
+		})
+	}
+}
+
+func TestAdoptLegacySaveFromSaveDir(t *testing.T) {
+	// Profiles already in the save folder are not moved onto themselves
+	dir := t.TempDir()
+	pm := NewProfileManager(dir)
+	writeSave(t, pm.path("alice"), 2)
+
+	moved, err := pm.AdoptLegacySave(dir)
+	if err != nil {
+		t.Fatalf("AdoptLegacySave: %v", err)
+	}
+	if len(moved) != 0 {
+		t.Errorf("moved %v, want nothing", moved)
+	}
+	if got := profileGold(pm, "alice"); got != 2 {
+		t.Errorf("profile has %d gold, want 2", got)
+	}
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// RecoveryAttempt records one backup the loader tried
+type RecoveryAttempt struct {
+	Path string
+	Err  error
+}
+
+// RecoveryReport describes what LoadWithRecovery had to do to get a player
+type RecoveryReport struct {
+	PrimaryError    error
+	QuarantinedPath string
+	Attempts        []RecoveryAttempt
+	RecoveredFrom   string
+	Player          string // Player.String() of the recovered save
+}
+
+// Recovered reports whether a backup could be loaded
+func (r *RecoveryReport) Recovered() bool {
+	return r.RecoveredFrom != ""
+}
+
+// Lines returns a human-readable account of the recovery
+func (r *RecoveryReport) Lines() []string {
+	var lines []string
+	lines = append(lines, fmt.Sprintf("Your save could not be loaded: %v", r.PrimaryError))
+	if r.QuarantinedPath != "" {
+		lines = append(lines, fmt.Sprintf("The damaged file was moved to %s", filepath.Base(r.QuarantinedPath)))
+	}
+
+	for _, attempt := range r.Attempts {
+		if attempt.Err != nil {
+			lines = append(lines, fmt.Sprintf("  ✗ %s: %v", filepath.Base(attempt.Path), attempt.Err))
+		} else {
+			lines = append(lines, fmt.Sprintf("  ✓ %s", filepath.Base(attempt.Path)))
+		}
+	}
+
+	if r.Recovered() {
+		lines = append(lines, fmt.Sprintf("Recovered %s from %s", r.Player, filepath.Base(r.RecoveredFrom)))
+	} else if len(r.Attempts) == 0 {
+		lines = append(lines, "No backups were found.")
+	} else {
+		lines = append(lines, "None of the backups could be loaded.")
+	}
+	return lines
+}
+
+// LoadWithRecovery loads the save, falling back to backups if it is damaged.
+// An unreadable save is moved aside to a timestamped quarantine path so it is
+// never overwritten. It returns a nil player and nil report when there is no
+// save at all, and a nil player with a report when nothing could be recovered.
+func (sm *SaveManager) LoadWithRecovery() (*models.Player, *RecoveryReport, error) {
+	candidates := sm.backupCandidates()
+
+	if !sm.Exists() {
+		if len(candidates) == 0 {
+			return nil, nil, nil
+		}
+		report := &RecoveryReport{PrimaryError: fmt.Errorf("save file not found")}
+		return sm.recoverFromBackups(report, candidates)
+	}
+
+	player, err := sm.Load()
+	if err == nil {
+		return player, nil, nil
+	}
+	if errors.Is(err, ErrSaveTooNew) {
+		// Not damaged, just unreadable by this build
+		return nil, nil, err
+	}
+
+	report := &RecoveryReport{PrimaryError: err}
+	quarantined, qerr := sm.quarantine()
+	if qerr != nil {
+		return nil, nil, fmt.Errorf("save is unreadable (%v) and could not be quarantined: %w", err, qerr)
+	}
+	report.QuarantinedPath = quarantined
+
+	return sm.recoverFromBackups(report, candidates)
+}
+
+// recoverFromBackups tries each candidate in order and stops at the first good one
+func (sm *SaveManager) recoverFromBackups(report *RecoveryReport, candidates []string) (*models.Player, *RecoveryReport, error) {
+	for _, path := range candidates {
+		player, err := LoadFile(path)
+		report.Attempts = append(report.Attempts, RecoveryAttempt{Path: path, Err: err})
+		if err == nil {
+			report.RecoveredFrom = path
+			report.Player = player.String()
+			return player, report, nil
+		}
+	}
+	return nil, report, nil
+}
+
+// backupCandidates lists existing backups, most recent first
+func (sm *SaveManager) backupCandidates() []string {
+	var candidates []string
+	for _, backup := range sm.ListBackups() {
+		candidates = append(candidates, backup.Path)
+	}
+	return candidates
+}
+
+// quarantine moves the live save to a timestamped path next to it
+func (sm *SaveManager) quarantine() (string, error) {
+	base := sm.SavePath + ".corrupt-" + time.Now().Format("20060102-150405")
+	path := base
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		path = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := os.Rename(sm.SavePath, path); err != nil {
+		return "", err
+	}
+	syncDir(filepath.Dir(path))
+	return path, nil
+}
+
+// LoadFile loads a player from any save file, such as a backup
+func LoadFile(path string) (*models.Player, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save: %w", err)
+	}
+	return decodeSave(data)
+}
+
+// SchemaVersionOf returns the schema version a save file was written with
+func SchemaVersionOf(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read save: %w", err)
+	}
+	var doc SaveDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("failed to parse save: %w", err)
+	}
+	return doc.SchemaVersion()
+}
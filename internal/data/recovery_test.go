@@ -0,0 +1,184 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// savedPlayer returns save file contents for a player with some gold
+func savedPlayer(t *testing.T, gold int64) string {
+	t.Helper()
+	player := models.NewPlayer("Test")
+	player.Gold = gold
+	data, err := encodeSave(player)
+	if err != nil {
+		t.Fatalf("failed to encode player: %v", err)
+	}
+	return string(data)
+}
+
+// writeRotatedBackup writes a rotated backup taken age before now
+func writeRotatedBackup(t *testing.T, sm *SaveManager, age time.Duration, contents string) string {
+	t.Helper()
+	if err := os.MkdirAll(sm.BackupDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stamp := time.Now().Add(-age).Format(backupTimeFormat)
+	path := filepath.Join(sm.BackupDir(), fmt.Sprintf("%shourly.%s.json", sm.backupPrefix(), stamp))
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadWithRecovery(t *testing.T) {
+	const damaged = `{"name": "Test", "skills": {`
+
+	tests := []struct {
+		name    string
+		save    string   // Empty for no save
+		backup  string   // The previous save's backup, if set
+		rotated []string // Rotated backups, newest first, an hour apart
+
+		wantGold       int64 // Gold of the player loaded, or -1 for none
+		wantReport     bool
+		wantQuarantine bool
+		wantAttempts   int
+	}{
+		{
+			name:     "healthy save",
+			save:     savedPlayer(t, 5),
+			backup:   savedPlayer(t, 4),
+			wantGold: 5,
+		},
+		{
+			name:     "no save at all",
+			wantGold: -1,
+		},
+		{
+			name:           "damaged save with a good backup",
+			save:           damaged,
+			backup:         savedPlayer(t, 4),
+			rotated:        []string{savedPlayer(t, 3)},
+			wantGold:       4,
+			wantReport:     true,
+			wantQuarantine: true,
+			wantAttempts:   1,
+		},
+		{
+			name:           "damaged save and backup",
+			save:           damaged,
+			backup:         damaged,
+			rotated:        []string{damaged, savedPlayer(t, 3), savedPlayer(t, 2)},
+			wantGold:       3,
+			wantReport:     true,
+			wantQuarantine: true,
+			wantAttempts:   3,
+		},
+		{
+			name:         "save missing with backups left",
+			rotated:      []string{savedPlayer(t, 3)},
+			wantGold:     3,
+			wantReport:   true,
+			wantAttempts: 1,
+		},
+		{
+			name:           "nothing recoverable",
+			save:           damaged,
+			rotated:        []string{damaged},
+			wantGold:       -1,
+			wantReport:     true,
+			wantQuarantine: true,
+			wantAttempts:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewSaveManager(t.TempDir())
+			if tt.save != "" {
+				if err := os.WriteFile(sm.SavePath, []byte(tt.save), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.backup != "" {
+				if err := os.WriteFile(sm.BackupPath(), []byte(tt.backup), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for i, contents := range tt.rotated {
+				writeRotatedBackup(t, sm, time.Duration(i+1)*time.Hour, contents)
+			}
+
+			player, report, err := sm.LoadWithRecovery()
+			if err != nil {
+				t.Fatalf("LoadWithRecovery: %v", err)
+			}
+
+			switch {
+			case tt.wantGold < 0 && player != nil:
+				t.Errorf("loaded a player with %d gold, want none", player.Gold)
+			case tt.wantGold >= 0 && player == nil:
+				t.Errorf("loaded no player, want one with %d gold", tt.wantGold)
+			case player != nil && player.Gold != tt.wantGold:
+				t.Errorf("loaded a player with %d gold, want %d", player.Gold, tt.wantGold)
+			}
+
+			if (report != nil) != tt.wantReport {
+				t.Fatalf("report = %v, want one: %v", report, tt.wantReport)
+			}
+			if report == nil {
+				return
+			}
+			if len(report.Attempts) != tt.wantAttempts {
+				t.Errorf("tried %d backups, want %d", len(report.Attempts), tt.wantAttempts)
+			}
+			if report.Recovered() != (tt.wantGold >= 0) {
+				t.Errorf("Recovered() = %v, want %v", report.Recovered(), tt.wantGold >= 0)
+			}
+
+			if !tt.wantQuarantine {
+				if report.QuarantinedPath != "" {
+					t.Errorf("quarantined %s, want nothing quarantined", report.QuarantinedPath)
+				}
+				return
+			}
+			if !strings.HasPrefix(report.QuarantinedPath, sm.SavePath+".corrupt-") {
+				t.Fatalf("quarantined to %q, want a timestamped path next to the save", report.QuarantinedPath)
+			}
+			if data, err := os.ReadFile(report.QuarantinedPath); err != nil || string(data) != tt.save {
+				t.Errorf("quarantined file holds %q (%v), want the damaged save", data, err)
+			}
+			if sm.Exists() {
+				t.Error("the damaged save was left in place")
+			}
+		})
+	}
+}
+
+func TestLoadWithRecoveryLeavesNewerSaves(t *testing.T) {
+	sm := NewSaveManager(t.TempDir())
+	save := `{"schema_version":99,"name":"Test"}`
+	if err := os.WriteFile(sm.SavePath, []byte(save), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeRotatedBackup(t, sm, time.Hour, savedPlayer(t, 3))
+
+	player, report, err := sm.LoadWithRecovery()
+	if !errors.Is(err, ErrSaveTooNew) {
+		t.Fatalf("err = %v, want %v", err, ErrSaveTooNew)
+	}
+	if player != nil || report != nil {
+		t.Errorf("recovered %v with report %v, want the newer save left alone", player, report)
+	}
+	if data, _ := os.ReadFile(sm.SavePath); string(data) != save {
+		t.Error("the newer save was moved or changed")
+	}
+}
@@ -5,49 +5,107 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 
+	"afk-tui/internal/config"
+	"afk-tui/internal/game"
 	"afk-tui/internal/models"
 )
 
 // SaveManager handles saving and loading game state
 type SaveManager struct {
-	SavePath string
+	SavePath    string
+	BackupTiers []BackupTier
+
+	// Saves may come from the UI and from background autosaves at once
+	mu          sync.Mutex
+	lastSavedAt time.Time
+}
+
+// DefaultSaveDir returns the XDG data directory, falling back to the
+// current directory if no home directory can be found
+func DefaultSaveDir() string {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "."
+	}
+	return dir
 }
 
-// NewSaveManager creates a new save manager
+// NewSaveManager creates a new save manager. An empty saveDir uses DefaultSaveDir.
 func NewSaveManager(saveDir string) *SaveManager {
 	if saveDir == "" {
-		saveDir = "."
+		saveDir = DefaultSaveDir()
 	}
 	return &SaveManager{
-		SavePath: filepath.Join(saveDir, "afk-tui-save.json"),
+		SavePath:    filepath.Join(saveDir, "afk-tui-save.json"),
+		BackupTiers: append([]BackupTier(nil), DefaultBackupTiers...),
 	}
 }
 
 // Save saves the player to disk
 func (sm *SaveManager) Save(player *models.Player) error {
+	player.UpdatePlaytime()
 	player.UpdateLastOnline()
+	return sm.SaveSnapshot(player, player.LastOnline)
+}
+
+// SaveSnapshot saves a player copy taken at takenAt without modifying it.
+// A snapshot older than the last completed save is skipped, so a slow
+// background save can never overwrite newer progress.
+func (sm *SaveManager) SaveSnapshot(player *models.Player, takenAt time.Time) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if takenAt.Before(sm.lastSavedAt) {
+		return nil
+	}
 
-	data, err := json.MarshalIndent(player, "", "  ")
+	data, err := encodeSave(player)
 	if err != nil {
 		return fmt.Errorf("failed to marshal player: %w", err)
 	}
 
-	// Create backup if save exists
-	if _, err := os.Stat(sm.SavePath); err == nil {
-		backupPath := sm.SavePath + ".backup"
-		os.Rename(sm.SavePath, backupPath)
+	// Copy the current save to the backup first. The live save stays in
+	// place until the new one is complete, so a crash never leaves us
+	// without a primary save.
+	if err := sm.backupCurrent(); err != nil {
+		return fmt.Errorf("failed to back up save: %w", err)
 	}
 
 	// Write new save
-	if err := os.WriteFile(sm.SavePath, data, 0644); err != nil {
+	if err := writeFileAtomic(sm.SavePath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write save: %w", err)
 	}
 
+	sm.lastSavedAt = takenAt
+
+	// Keep timestamped copies for the restore screen
+	if err := sm.rotateBackups(data, time.Now()); err != nil {
+		return fmt.Errorf("saved, but failed to rotate backups: %w", err)
+	}
+
 	return nil
 }
 
+// BackupPath returns the path of the backup kept from the previous save
+func (sm *SaveManager) BackupPath() string {
+	return sm.SavePath + ".backup"
+}
+
+// backupCurrent copies the existing save, if any, over the backup
+func (sm *SaveManager) backupCurrent() error {
+	if _, err := os.Stat(sm.SavePath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return copyFileAtomic(sm.SavePath, sm.BackupPath(), 0644)
+}
+
 // Load loads the player from disk
 func (sm *SaveManager) Load() (*models.Player, error) {
 	data, err := os.ReadFile(sm.SavePath)
@@ -58,36 +116,71 @@ func (sm *SaveManager) Load() (*models.Player, error) {
 		return nil, fmt.Errorf("failed to read save: %w", err)
 	}
 
-	var player models.Player
-	if err := json.Unmarshal(data, &player); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal save: %w", err)
-	}
+	return decodeSave(data)
+}
 
-	// Initialize ActivityLog if nil (for old saves)
-	if player.ActivityLog == nil {
-		player.ActivityLog = models.NewActivityLog()
-		player.ActivityLog.AddEntry(models.LogTypeSystem, "Save loaded - Activity log initialized", nil)
-	}
+// EncodePlayer serializes a player on one line the way saves store it, for
+// embedding in other files such as session recordings
+func EncodePlayer(player *models.Player) ([]byte, error) {
+	return json.Marshal(saveFile{
+		SchemaVersion: CurrentSchemaVersion,
+		Player:        player,
+	})
+}
 
-	// Initialize CombatStats if nil (for old saves)
-	if player.CombatStats == nil {
-		player.CombatStats = models.NewCombatStats()
-	}
+// DecodePlayer reads a player written by EncodePlayer or any save file,
+// upgrading older schemas, and prepares it for play
+func DecodePlayer(data []byte) (*models.Player, error) {
+	return decodeSave(data)
+}
 
-	// Initialize Attributes if nil (for old saves)
-	if player.Attributes == nil {
-		player.Attributes = models.NewCharacterAttributes()
+// decodeSave migrates raw save JSON to the current schema and unmarshals it
+func decodeSave(data []byte) (*models.Player, error) {
+	player, err := unmarshalSave(data)
+	if err != nil {
+		return nil, err
 	}
+	linkPlayer(player)
+	return player, nil
+}
 
-	// Restore current activity if present
-	if player.CurrentActivity != nil {
-		// Re-link activity to template
-		player.CurrentActivity = models.NewActivity(player.CurrentActivity.ID)
+// unmarshalSave migrates and unmarshals a save exactly as stored,
+// without re-linking anything to the content databases
+func unmarshalSave(data []byte) (*models.Player, error) {
+	migrated, _, err := MigrateSave(data)
+	if err != nil {
+		return nil, err
 	}
 
+	var player models.Player
+	if err := json.Unmarshal(migrated, &player); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal save: %w", err)
+	}
 	return &player, nil
 }
 
+// linkPlayer prepares a freshly unmarshalled player for play
+func linkPlayer(player *models.Player) {
+	player.SessionStart = time.Now()
+
+	// Perks added since the player passed their level
+	player.UnlockEarnedPerks()
+
+	// Re-link the current activity to its template, keeping how far
+	// along the current action was
+	if saved := player.CurrentActivity; saved != nil {
+		activity := models.NewActivity(saved.ID)
+		if activity != nil && player.Equipment != nil {
+			activity.ApplyModifiers(player)
+			if saved.TicksRemaining > 0 && saved.TicksRemaining <= activity.TicksRemaining {
+				activity.TicksRemaining = saved.TicksRemaining
+				activity.Progress = saved.Progress
+			}
+		}
+		player.CurrentActivity = activity
+	}
+}
+
 // Exists checks if save file exists
 func (sm *SaveManager) Exists() bool {
 	_, err := os.Stat(sm.SavePath)
@@ -101,95 +194,352 @@ func (sm *SaveManager) Delete() error {
 
 // OfflineProcessor handles offline progress calculation
 type OfflineProcessor struct {
-	MaxOfflineTime time.Duration
+	MaxOfflineTime time.Duration // Base cap, before the player's upgrades
+	TickRate       time.Duration
+	Rand           models.RNG // Draws drops, double and triple drops and combat rolls
+
+	// Now returns the time offline progress is measured up to. Nil uses
+	// the clock; replays fix it to when the session was recorded.
+	Now func() time.Time
 }
 
 // NewOfflineProcessor creates processor with default 24h max
 func NewOfflineProcessor() *OfflineProcessor {
 	return &OfflineProcessor{
 		MaxOfflineTime: 24 * time.Hour,
+		TickRate:       time.Second,
+		Rand:           models.NewRand(models.NewSeed()),
 	}
 }
 
-// CalculateOfflineProgress calculates what happened while away
+// CapFor returns the player's offline cap: the base MaxOfflineTime plus
+// upgrades bought with gold and perks
+func (op *OfflineProcessor) CapFor(player *models.Player) time.Duration {
+	return op.MaxOfflineTime + player.OfflineCapBonus()
+}
+
+// CalculateOfflineProgress simulates the time away tick by tick with the
+// same rules as the live game, so levels and perks gained while away take
+// effect from the next action
 func (op *OfflineProcessor) CalculateOfflineProgress(player *models.Player) *OfflineResult {
-	offlineDuration := time.Since(player.LastOnline)
-	if offlineDuration > op.MaxOfflineTime {
-		offlineDuration = op.MaxOfflineTime
+	// Time away measured by a clock that can't be trusted doesn't count
+	now := time.Now()
+	if op.Now != nil {
+		now = op.Now()
+	}
+	if problem := player.CheckClock(now); problem != "" {
+		player.ResetClock(now)
+		return &OfflineResult{ClockWarning: problem}
 	}
 
-	if player.CurrentActivity == nil || offlineDuration < time.Second {
+	offlineDuration := now.Sub(player.LastOnline)
+	var discarded time.Duration
+	if limit := op.CapFor(player); offlineDuration > limit {
+		player.NoteLongAbsence(now, offlineDuration, limit)
+		discarded = offlineDuration - limit
+		offlineDuration = limit
+	}
+
+	fighting := isFighting(player)
+	if (player.CurrentActivity == nil && !fighting) || offlineDuration < time.Second {
 		return &OfflineResult{
 			OfflineTime:    0,
 			TicksProcessed: 0,
 		}
 	}
 
-	activity := player.CurrentActivity
-	activity.ApplyModifiers(player)
-
-	// Calculate how many ticks fit in offline time
-	// Assume tick rate of 1 second for simplicity
-	tickRate := time.Second
+	tickRate := op.TickRate
+	if tickRate <= 0 {
+		tickRate = time.Second
+	}
 	totalTicks := int(offlineDuration / tickRate)
 
-	// Calculate actions completed
-	ticksPerAction := activity.TicksRemaining
-	if ticksPerAction <= 0 {
-		ticksPerAction = 1
+	result := &OfflineResult{
+		OfflineTime:   offlineDuration,
+		Discarded:     discarded,
+		ItemsGained:   make(map[string]int),
+		ItemsLost:     make(map[string]int),
+		ItemsSold:     make(map[string]int),
+		ItemsBanked:   make(map[string]int),
+		ItemsConsumed: make(map[string]int),
+	}
+
+	startXP := make(map[models.SkillType]int64, len(player.Skills))
+	startLevel := make(map[models.SkillType]int, len(player.Skills))
+	for skillType, skill := range player.Skills {
+		startXP[skillType] = skill.TotalXP()
+		startLevel[skillType] = skill.Level
 	}
-	actionsCompleted := totalTicks / ticksPerAction
 
-	// Calculate XP gained
-	xpPerAction := activity.GetXP()
-	totalXP := xpPerAction * int64(actionsCompleted)
+	// A fight in progress pauses the activity, as it does in the live game
+	if fighting {
+		simulateCombat(player, result, totalTicks, tickRate, op.Rand)
+	} else {
+		simulateActivity(player, result, totalTicks, tickRate, op.Rand)
+	}
 
-	// Calculate items gained
-	outputPerAction := activity.GetOutput()
-	totalItems := make(map[string]int)
-	for itemID, qty := range outputPerAction {
-		totalItems[itemID] = qty * actionsCompleted
+	result.SkillXP = make(map[models.SkillType]int64)
+	result.LevelsGained = make(map[models.SkillType]int)
+	for skillType, skill := range player.Skills {
+		if xp := skill.TotalXP() - startXP[skillType]; xp > 0 {
+			result.SkillXP[skillType] = xp
+		}
+		if levels := skill.Level - startLevel[skillType]; levels > 0 {
+			result.LevelsGained[skillType] = levels
+		}
 	}
+	return result
+}
 
-	// Apply XP and items to player
-	perks := player.AddXP(activity.SkillType, totalXP)
+// simulateActivity runs the current activity for up to totalTicks. Double
+// and triple drops are drawn for all actions at the end rather than rolled
+// one by one.
+func simulateActivity(player *models.Player, result *OfflineResult, totalTicks int, tickRate time.Duration, rng models.RNG) {
+	activity := player.CurrentActivity
+	result.ActivityName = activity.Name
+	result.SkillName = models.SkillNames[activity.SkillType]
+	result.SkillType = activity.SkillType
+
+	drops := &game.BulkDrops{}
+
+	for result.TicksProcessed < totalTicks {
+		tick := game.TickActivity(player, drops)
+		result.TicksProcessed++
+
+		if tick.StoppedFor != "" {
+			result.StopReason = fmt.Sprintf("Ran out of %s", models.ItemName(tick.StoppedFor))
+			result.StoppedAfter = time.Duration(result.TicksProcessed) * tickRate
+			break
+		}
+
+		for itemID, qty := range tick.Consumed {
+			result.ItemsConsumed[itemID] += qty
+		}
+		if !tick.Completed {
+			continue
+		}
 
-	var failedItems []string
-	for itemID, qty := range totalItems {
-		item := models.NewItem(itemID, "", qty)
-		if !player.Inventory.AddItem(item) {
-			failedItems = append(failedItems, itemID)
+		result.ActionsCompleted++
+		result.XPGained += tick.XP
+		if tick.Attribute != "" {
+			result.Attribute = tick.Attribute
+			result.AttributeXP += tick.AttributeXP
+			result.AttributeLevels += tick.NewAttributeLevel - tick.OldAttributeLevel
+			result.AttributeLevel = tick.NewAttributeLevel
+		}
+		result.PerksUnlocked = append(result.PerksUnlocked, tick.Perks...)
+		result.addHaul(&tick.Haul)
+
+		if tick.Stop {
+			result.StopReason = "Inventory full"
+			result.StoppedAfter = time.Duration(result.TicksProcessed) * tickRate
+			break
 		}
 	}
 
-	return &OfflineResult{
-		OfflineTime:      offlineDuration,
-		TicksProcessed:   totalTicks,
-		ActionsCompleted: actionsCompleted,
-		XPGained:         totalXP,
-		ItemsGained:      totalItems,
-		PerksUnlocked:    perks,
-		FailedItems:      failedItems,
-		ActivityName:     activity.Name,
-		SkillName:        models.SkillNames[activity.SkillType],
-		SkillType:        activity.SkillType,
+	// Bonus output overflows like any other, but can no longer stop the
+	// actions it was drawn for
+	bonus := drops.Sample(rng)
+	result.TripleDrops = bonus.Triples
+	result.DoubleDrops = bonus.Doubles
+	var haul models.Haul
+	for _, itemID := range models.SortedItemIDs(bonus.Items) {
+		player.Store(&haul, itemID, bonus.Items[itemID])
 	}
+	result.addHaul(&haul)
 }
 
 // OfflineResult contains offline calculation results
 type OfflineResult struct {
 	OfflineTime      time.Duration
+	Discarded        time.Duration // Time away beyond the offline cap
+	ClockWarning     string        // Why the time away was not trusted, if it wasn't
 	TicksProcessed   int
 	ActionsCompleted int
 	XPGained         int64
+	SkillXP          map[models.SkillType]int64 // XP gained per skill
+	LevelsGained     map[models.SkillType]int
 	ItemsGained      map[string]int
 	PerksUnlocked    []models.Perk
-	FailedItems      []string
+	FailedItems      []string       // IDs of items that did not fit
+	ItemsLost        map[string]int // Quantities that did not fit and were dropped
+	ItemsSold        map[string]int // Overflow sold by the overflow policy
+	ItemsBanked      map[string]int // Overflow sent to the bank
+	OverflowGold     int64          // Gold from the overflow sold
+	ItemsConsumed    map[string]int
+	DoubleDrops      int    // Actions that paid out double
+	TripleDrops      int    // Actions that paid out triple
+	Attribute        string // Attribute raised by a training activity
+	AttributeXP      int64
+	AttributeLevels  int  // Attribute levels gained
+	AttributeLevel   int  // Attribute level at the end
+	InCombat         bool // The time was spent fighting instead of on the activity
+	Kills            int
+	Deaths           int
+	GoldGained       int64
+	SlayerXP         int64
+	SlayerLevels     int
+	StopReason       string        // Why the activity stopped early, if it did
+	StoppedAfter     time.Duration // Offline time at which it stopped
 	ActivityName     string
 	SkillName        string
 	SkillType        models.SkillType
 }
 
+// Events returns what happened while away as game events: an
+// OfflineProgress summary, then the XP, levels, items and perks gained.
+// Publishing them on the game's bus logs them like online play.
+func (or *OfflineResult) Events(player *models.Player) []game.Event {
+	if or.ClockWarning != "" {
+		flags := 0
+		if player.Clock != nil {
+			flags = player.Clock.Flags
+		}
+		return []game.Event{game.OfflineProgressSkipped{Reason: or.ClockWarning, ClockFlags: flags}}
+	}
+	if or.OfflineTime <= 0 {
+		return nil
+	}
+
+	events := []game.Event{game.OfflineProgress{
+		Away:         or.OfflineTime,
+		Discarded:    or.Discarded,
+		Activity:     or.ActivityName,
+		Skill:        or.SkillName,
+		Actions:      or.ActionsCompleted,
+		XP:           or.XPGained,
+		InCombat:     or.InCombat,
+		Kills:        or.Kills,
+		Deaths:       or.Deaths,
+		Gold:         or.GoldGained,
+		SlayerXP:     or.SlayerXP,
+		SlayerLevels: or.SlayerLevels,
+		StopReason:   or.StopReason,
+		StoppedAfter: or.StoppedAfter,
+		Consumed:     or.ItemsConsumed,
+	}}
+
+	skills := make([]string, 0, len(or.SkillXP))
+	for skillType := range or.SkillXP {
+		skills = append(skills, string(skillType))
+	}
+	sort.Strings(skills)
+	for _, name := range skills {
+		skillType := models.SkillType(name)
+		level := player.GetSkill(skillType).Level
+		events = append(events, game.XPGained{Skill: skillType, Amount: or.SkillXP[skillType], Level: level, Offline: true})
+		if or.LevelsGained[skillType] > 0 {
+			events = append(events, game.LevelUp{Skill: skillType, Level: level})
+		}
+	}
+	if or.Attribute != "" {
+		events = append(events, game.XPGained{Attribute: or.Attribute, Amount: or.AttributeXP, Level: or.AttributeLevel, Offline: true})
+		if or.AttributeLevels > 0 {
+			events = append(events, game.LevelUp{Attribute: or.Attribute, Level: or.AttributeLevel})
+		}
+	}
+
+	haul := &models.Haul{Items: or.ItemsGained, Sold: or.ItemsSold, Banked: or.ItemsBanked, Lost: or.ItemsLost}
+	for _, event := range game.HaulEvents(haul, player.Overflow()) {
+		if gained, ok := event.(game.ItemGained); ok {
+			gained.Offline = true
+			event = gained
+		}
+		events = append(events, event)
+	}
+
+	for _, perk := range or.PerksUnlocked {
+		events = append(events, game.PerkUnlocked{Perk: perk, Skill: perk.SkillType})
+	}
+	return events
+}
+
+// LootLine is one item in the offline loot table
+type LootLine struct {
+	ItemID string
+	Name   string
+	Gained int   // Added to the inventory
+	Sold   int   // Did not fit and was sold
+	Banked int   // Did not fit and went to the bank
+	Lost   int   // Did not fit and was dropped
+	Value  int64 // Gold value of one item, 0 if it has none
+}
+
+// Loot lists every item gained, sold, banked or lost while away, sorted by
+// name
+func (or *OfflineResult) Loot() []LootLine {
+	lines := make(map[string]*LootLine)
+	line := func(itemID string) *LootLine {
+		if l, ok := lines[itemID]; ok {
+			return l
+		}
+		l := &LootLine{ItemID: itemID, Name: models.ItemName(itemID)}
+		if item := models.GetItemTemplate(itemID); item != nil {
+			l.Value = item.Value
+		}
+		lines[itemID] = l
+		return l
+	}
+	for itemID, qty := range or.ItemsGained {
+		line(itemID).Gained += qty
+	}
+	for itemID, qty := range or.ItemsSold {
+		line(itemID).Sold += qty
+	}
+	for itemID, qty := range or.ItemsBanked {
+		line(itemID).Banked += qty
+	}
+	for itemID, qty := range or.ItemsLost {
+		line(itemID).Lost += qty
+	}
+
+	loot := make([]LootLine, 0, len(lines))
+	for _, l := range lines {
+		loot = append(loot, *l)
+	}
+	sort.Slice(loot, func(i, j int) bool {
+		if loot[i].Name != loot[j].Name {
+			return loot[i].Name < loot[j].Name
+		}
+		return loot[i].ItemID < loot[j].ItemID
+	})
+	return loot
+}
+
+// LootValue is the gold value of the items kept
+func (or *OfflineResult) LootValue() int64 {
+	var total int64
+	for _, line := range or.Loot() {
+		total += int64(line.Gained) * line.Value
+	}
+	return total
+}
+
+// addHaul records where the items from a tick went
+func (or *OfflineResult) addHaul(haul *models.Haul) {
+	for itemID, qty := range haul.Items {
+		or.ItemsGained[itemID] += qty
+	}
+	for itemID, qty := range haul.Sold {
+		or.ItemsSold[itemID] += qty
+	}
+	for itemID, qty := range haul.Banked {
+		or.ItemsBanked[itemID] += qty
+	}
+	or.OverflowGold += haul.SaleGold
+	or.addLost(haul.Lost)
+}
+
+// addLost records items that did not fit in the inventory
+func (or *OfflineResult) addLost(lost map[string]int) {
+	for itemID, qty := range lost {
+		if or.ItemsLost[itemID] == 0 {
+			or.FailedItems = append(or.FailedItems, itemID)
+		}
+		or.ItemsLost[itemID] += qty
+	}
+}
+
 // String returns formatted offline summary
 func (or *OfflineResult) String() string {
 	if or.OfflineTime == 0 {
@@ -203,25 +553,56 @@ func (or *OfflineResult) String() string {
 	if hours > 0 {
 		summary += fmt.Sprintf("%dh ", hours)
 	}
-	summary += fmt.Sprintf("%dm\n\n", minutes)
+	summary += fmt.Sprintf("%dm\n", minutes)
+	if or.Discarded > 0 {
+		summary += fmt.Sprintf("(%s beyond your offline cap was not counted)\n", or.Discarded.Round(time.Minute))
+	}
+	summary += "\n"
 
 	summary += fmt.Sprintf("While you were away:\n")
 	summary += fmt.Sprintf("  Activity: %s (%s)\n", or.ActivityName, or.SkillName)
 	summary += fmt.Sprintf("  Actions: %d\n", or.ActionsCompleted)
 	summary += fmt.Sprintf("  XP Gained: %d\n", or.XPGained)
+	if or.Attribute != "" {
+		summary += fmt.Sprintf("  %s XP: %d", or.Attribute, or.AttributeXP)
+		if or.AttributeLevels > 0 {
+			summary += fmt.Sprintf(" (+%d levels)", or.AttributeLevels)
+		}
+		summary += "\n"
+	}
+
+	if or.InCombat {
+		summary += fmt.Sprintf("  Kills: %d, Deaths: %d\n", or.Kills, or.Deaths)
+		summary += fmt.Sprintf("  Gold: %d\n", or.GoldGained)
+		summary += fmt.Sprintf("  Slayer XP: %d", or.SlayerXP)
+		if or.SlayerLevels > 0 {
+			summary += fmt.Sprintf(" (+%d levels)", or.SlayerLevels)
+		}
+		summary += "\n"
+	}
 
 	if len(or.ItemsGained) > 0 {
 		summary += "  Items Gained:\n"
 		for itemID, qty := range or.ItemsGained {
-			item := models.GetItemTemplate(itemID)
-			name := itemID
-			if item != nil {
-				name = item.Name
-			}
-			summary += fmt.Sprintf("    - %d %s\n", qty, name)
+			summary += fmt.Sprintf("    - %d %s\n", qty, models.ItemName(itemID))
 		}
 	}
 
+	if or.DoubleDrops > 0 || or.TripleDrops > 0 {
+		summary += fmt.Sprintf("  Bonus Drops: %d double, %d triple\n", or.DoubleDrops, or.TripleDrops)
+	}
+
+	if len(or.ItemsConsumed) > 0 {
+		summary += "  Items Used:\n"
+		for itemID, qty := range or.ItemsConsumed {
+			summary += fmt.Sprintf("    - %d %s\n", qty, models.ItemName(itemID))
+		}
+	}
+
+	if or.StopReason != "" {
+		summary += fmt.Sprintf("  Stopped after %s: %s\n", or.StoppedAfter.Round(time.Second), or.StopReason)
+	}
+
 	if len(or.PerksUnlocked) > 0 {
 		summary += "  Perks Unlocked:\n"
 		for _, perk := range or.PerksUnlocked {
@@ -229,6 +610,12 @@ func (or *OfflineResult) String() string {
 		}
 	}
 
+	if len(or.ItemsSold) > 0 {
+		summary += fmt.Sprintf("  Inventory full: overflow sold for %d gold\n", or.OverflowGold)
+	}
+	if len(or.ItemsBanked) > 0 {
+		summary += "  Inventory full: overflow sent to the bank\n"
+	}
 	if len(or.FailedItems) > 0 {
 		summary += "  (Inventory was full for some items)\n"
 	}
@@ -0,0 +1,70 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// awayPlayer returns a player who left doing an activity some time before now
+func awayPlayer(activityID string, away time.Duration, now time.Time) *models.Player {
+	player := models.NewPlayer("Test")
+	player.Clock = nil
+	player.LastOnline = now.Add(-away)
+	player.CurrentActivity = models.NewActivity(activityID)
+	player.CurrentActivity.ApplyModifiers(player)
+	return player
+}
+
+// fixedProcessor measures offline progress up to now with seeded randomness
+func fixedProcessor(now time.Time) *OfflineProcessor {
+	op := NewOfflineProcessor()
+	op.Rand = models.NewRand(1)
+	op.Now = func() time.Time { return now }
+	return op
+}
+
+func TestOfflineLevelUpShortensLaterActions(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	const ticks = 100
+
+	// One action short of level 5, which unlocks Quick Chop
+	player := awayPlayer("chop_logs", ticks*time.Second, now)
+	skill := player.Skills[models.SkillWoodcutting]
+	skill.SetLevel(4)
+	skill.XP = skill.XPToNext - 1
+	player.CurrentActivity.ApplyModifiers(player)
+	slow := player.CurrentActivity.EffectiveTicks()
+
+	result := fixedProcessor(now).CalculateOfflineProgress(player)
+
+	if skill.Level < 5 {
+		t.Fatalf("woodcutting level %d after the first action, want at least 5", skill.Level)
+	}
+	fast := player.CurrentActivity.EffectiveTicks()
+	if fast >= slow {
+		t.Fatalf("actions take %d ticks after the level up, want fewer than %d", fast, slow)
+	}
+	if remaining := player.CurrentActivity.TicksRemaining; remaining > fast {
+		t.Errorf("%d ticks remaining on the last action, want at most %d", remaining, fast)
+	}
+
+	// The first action at the old speed, every later one at the new
+	want := 1 + (ticks-slow)/fast
+	if result.ActionsCompleted != want {
+		t.Errorf("%d actions completed, want %d", result.ActionsCompleted, want)
+	}
+}
+
+func BenchmarkOffline24h(b *testing.B) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		player := awayPlayer("chop_logs", 24*time.Hour, now)
+		op := fixedProcessor(now)
+		b.StartTimer()
+
+		op.CalculateOfflineProgress(player)
+	}
+}
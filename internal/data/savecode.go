@@ -0,0 +1,108 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+
+	"afk-tui/internal/models"
+)
+
+// saveCodePrefix starts every save code and names its format version
+const saveCodePrefix = "AFK1:"
+
+// maxSaveCodeSize caps how much a save code may decompress to
+const maxSaveCodeSize = 16 << 20
+
+// ErrInvalidSaveCode is returned for text that is not a save code
+var ErrInvalidSaveCode = errors.New("not a valid save code")
+
+// ExportCode encodes a player as a single line of text that can be pasted
+// on another machine: "AFK1:<crc32>:<base64 of the gzipped save>".
+// The activity log is left out; it is history, and would make the code
+// over ten times longer.
+func ExportCode(player *models.Player) (string, error) {
+	withoutLog := *player
+	withoutLog.ActivityLog = nil
+
+	data, err := encodeSave(&withoutLog)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal player: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return "", fmt.Errorf("failed to compress save: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress save: %w", err)
+	}
+
+	return fmt.Sprintf("%s%08x:%s", saveCodePrefix, crc32.ChecksumIEEE(data),
+		base64.StdEncoding.EncodeToString(buf.Bytes())), nil
+}
+
+// ImportCode decodes a save code. The save is migrated to the current
+// schema and checked with ValidatePlayer, so a player is only returned if
+// it is safe to play in this build. Whitespace is ignored, so codes that
+// were wrapped over several lines still import.
+func ImportCode(code string) (*models.Player, error) {
+	code = strings.Join(strings.Fields(code), "")
+	if !strings.HasPrefix(code, saveCodePrefix) {
+		return nil, fmt.Errorf("%w: it should start with %s", ErrInvalidSaveCode, saveCodePrefix)
+	}
+
+	checksum, payload, ok := strings.Cut(strings.TrimPrefix(code, saveCodePrefix), ":")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing checksum", ErrInvalidSaveCode)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v (was it copied completely?)", ErrInvalidSaveCode, err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSaveCode, err)
+	}
+	data, err := io.ReadAll(io.LimitReader(zr, maxSaveCodeSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSaveCode, err)
+	}
+	if len(data) > maxSaveCodeSize {
+		return nil, fmt.Errorf("%w: save is too large", ErrInvalidSaveCode)
+	}
+
+	if fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)) != strings.ToLower(checksum) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSaveCode)
+	}
+
+	player, err := unmarshalSave(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePlayer(player); err != nil {
+		return nil, err
+	}
+	linkPlayer(player)
+	if player.ActivityLog == nil {
+		player.ActivityLog = models.NewActivityLog()
+	}
+
+	// The clock history came from another machine's clock; keep only the
+	// count of suspicious events
+	if player.Clock != nil {
+		player.Clock.Checkpoints = nil
+	}
+	return player, nil
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"afk-tui/internal/models"
+)
+
+func TestSaveCodeRoundTrip(t *testing.T) {
+	player := models.NewPlayer("Traveller")
+	player.Gold = 1234
+	player.Skills[models.SkillMining].SetLevel(30)
+
+	code, err := ExportCode(player)
+	if err != nil {
+		t.Fatalf("ExportCode: %v", err)
+	}
+	if strings.ContainsAny(code, " \n") {
+		t.Error("save code is not a single line")
+	}
+
+	// Codes pasted from a chat window are often wrapped
+	wrapped := code[:20] + "\n  " + code[20:]
+	imported, err := ImportCode(wrapped)
+	if err != nil {
+		t.Fatalf("ImportCode: %v", err)
+	}
+	if imported.Name != player.Name || imported.Gold != player.Gold || imported.Skills[models.SkillMining].Level != 30 {
+		t.Errorf("imported %s with %d gold, want %s with %d gold and level 30 mining",
+			imported, imported.Gold, player, player.Gold)
+	}
+	if imported.ActivityLog == nil {
+		t.Error("imported player has no activity log")
+	}
+}
+
+func TestImportCodeRejects(t *testing.T) {
+	valid, err := ExportCode(models.NewPlayer("Traveller"))
+	if err != nil {
+		t.Fatalf("ExportCode: %v", err)
+	}
+	checksum, payload, _ := strings.Cut(strings.TrimPrefix(valid, saveCodePrefix), ":")
+
+	invalidPlayer := models.NewPlayer("Traveller")
+	invalidPlayer.Gold = -5
+	invalid, err := ExportCode(invalidPlayer)
+	if err != nil {
+		t.Fatalf("ExportCode: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error // Nil for a *ValidationError
+	}{
+		{"empty", "", ErrInvalidSaveCode},
+		{"wrong prefix", "AFK0:" + checksum + ":" + payload, ErrInvalidSaveCode},
+		{"no checksum", saveCodePrefix + payload, ErrInvalidSaveCode},
+		{"cut short", valid[:len(valid)/2], ErrInvalidSaveCode},
+		{"wrong checksum", saveCodePrefix + "00000000:" + payload, ErrInvalidSaveCode},
+		{"invalid player", invalid, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, err := ImportCode(tt.code)
+			if player != nil {
+				t.Fatal("imported a player")
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			var validation *ValidationError
+			if !errors.As(err, &validation) {
+				t.Errorf("err = %v, want a validation error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"afk-tui/internal/models"
+)
+
+// ValidationError lists everything wrong with a player
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Problems) == 1 {
+		return "invalid save: " + e.Problems[0]
+	}
+	return fmt.Sprintf("invalid save: %d problems: %s", len(e.Problems), strings.Join(e.Problems, "; "))
+}
+
+// ValidatePlayer checks a player against the content databases: skills,
+// items, perks, activities and monsters must all exist in this build and
+// levels and quantities must be in range. It returns a *ValidationError.
+func ValidatePlayer(player *models.Player) error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if strings.TrimSpace(player.Name) == "" {
+		add("player has no name")
+	}
+	if player.Gold < 0 {
+		add("negative gold (%d)", player.Gold)
+	}
+	if player.TotalPlaytime < 0 {
+		add("negative playtime")
+	}
+	if player.OfflineCapUpgrades < 0 || player.OfflineCapUpgrades > models.MaxOfflineCapUpgrades {
+		add("offline cap upgrades out of range (%d)", player.OfflineCapUpgrades)
+	}
+
+	if len(player.Skills) == 0 {
+		add("no skills")
+	}
+	skillTypes := make([]string, 0, len(player.Skills))
+	for skillType := range player.Skills {
+		skillTypes = append(skillTypes, string(skillType))
+	}
+	sort.Strings(skillTypes)
+	for _, name := range skillTypes {
+		skillType := models.SkillType(name)
+		skill := player.Skills[skillType]
+		if _, ok := models.SkillNames[skillType]; !ok {
+			add("unknown skill %q", skillType)
+			continue
+		}
+		if skill == nil {
+			add("skill %s is empty", skillType)
+			continue
+		}
+		if skill.Level < 1 || skill.Level > 120 {
+			add("%s level %d is out of range", skillType, skill.Level)
+		}
+		if skill.XP < 0 {
+			add("%s has negative XP", skillType)
+		}
+	}
+
+	if player.Inventory == nil {
+		add("no inventory")
+	} else {
+		if player.Inventory.MaxSlots < 1 {
+			add("inventory has no slots")
+		}
+		if len(player.Inventory.Items) > player.Inventory.MaxSlots {
+			add("inventory holds %d stacks but has %d slots", len(player.Inventory.Items), player.Inventory.MaxSlots)
+		}
+		for _, item := range player.Inventory.Items {
+			validateItem(item, "inventory", add)
+		}
+	}
+
+	if player.Bank != nil {
+		for _, item := range player.Bank.Items {
+			validateItem(item, "bank", add)
+		}
+	}
+	if player.OverflowPolicy != "" && player.Overflow() != player.OverflowPolicy {
+		add("unknown overflow policy %q", player.OverflowPolicy)
+	}
+
+	if player.Equipment == nil {
+		add("no equipment")
+	} else {
+		for _, slot := range models.EquipmentSlots {
+			if item := player.Equipment.GetSlot(slot); item != nil {
+				validateItem(item, string(slot)+" slot", add)
+			}
+		}
+	}
+
+	for _, perk := range player.UnlockedPerks {
+		if _, ok := models.GetPerkByID(perk.ID); !ok {
+			add("unknown perk %q", perk.ID)
+		}
+	}
+
+	if player.CurrentActivity != nil {
+		if _, ok := models.ActivityDatabase[player.CurrentActivity.ID]; !ok {
+			add("unknown activity %q", player.CurrentActivity.ID)
+		}
+	}
+
+	if player.CombatStats == nil {
+		add("no combat stats")
+	} else if task := player.CombatStats.CurrentTask; task != nil && models.Monsters.GetMonster(task.MonsterID) == nil {
+		add("slayer task for unknown monster %q", task.MonsterID)
+	}
+
+	if resume := player.Resume; resume != nil {
+		if resume.MonsterID != "" && models.Monsters.GetMonster(resume.MonsterID) == nil {
+			add("selected monster %q is unknown", resume.MonsterID)
+		}
+		if resume.Combat != nil && models.Monsters.GetMonster(resume.Combat.MonsterID) == nil {
+			add("fight against unknown monster %q", resume.Combat.MonsterID)
+		}
+	}
+
+	if player.Attributes == nil {
+		add("no attributes")
+	} else {
+		attrs := player.Attributes
+		for _, attr := range []struct {
+			name  string
+			level int
+		}{
+			{"strength", attrs.Strength.Level},
+			{"dexterity", attrs.Dexterity.Level},
+			{"defense", attrs.Defense.Level},
+			{"constitution", attrs.Constitution.Level},
+			{"intelligence", attrs.Intelligence.Level},
+		} {
+			if attr.level < 1 || attr.level > 120 {
+				add("%s level %d is out of range", attr.name, attr.level)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateItem checks a single item stack
+func validateItem(item *models.Item, where string, add func(string, ...interface{})) {
+	if item == nil {
+		add("empty item in %s", where)
+		return
+	}
+	if !models.IsKnownItem(item.ID) {
+		add("unknown item %q in %s", item.ID, where)
+	}
+	if item.Quantity < 1 {
+		add("%s has quantity %d in %s", item.ID, item.Quantity, where)
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AutosaveMsg is sent every AutosaveInterval
+type AutosaveMsg struct{}
+
+// SaveResultMsg reports a finished background save
+type SaveResultMsg struct {
+	Time time.Time
+	Err  error
+}
+
+// autosaveCmd schedules the next autosave check. A zero interval disables autosave.
+func autosaveCmd(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return AutosaveMsg{}
+	})
+}
+
+// handleAutosave starts a background save if anything changed since the last
+// one. Nothing is saved while the recovery screen is waiting for an answer, so
+// a damaged save isn't replaced before the player has decided.
+func (m *Model) handleAutosave() (*Model, tea.Cmd) {
+	var saveCmd tea.Cmd
+	if m.Dirty && !m.SaveInFlight && m.State != StateRecovery {
+		saveCmd = m.saveInBackground()
+	}
+	return m, tea.Batch(saveCmd, autosaveCmd(m.AutosaveInterval))
+}
+
+// saveInBackground snapshots the player on the update loop and writes the
+// snapshot from a command, so disk I/O never delays ticks
+func (m *Model) saveInBackground() tea.Cmd {
+	m.captureResumeState()
+	m.Player.UpdatePlaytime()
+	m.Player.UpdateLastOnline()
+	snapshot := m.Player.Clone()
+	takenAt := m.Player.LastOnline
+	saveManager := m.SaveManager
+
+	m.Dirty = false
+	m.SaveInFlight = true
+
+	return func() tea.Msg {
+		err := saveManager.SaveSnapshot(snapshot, takenAt)
+		return SaveResultMsg{Time: takenAt, Err: err}
+	}
+}
+
+// handleSaveResult records the outcome of a background save
+func (m *Model) handleSaveResult(msg SaveResultMsg) (*Model, tea.Cmd) {
+	m.SaveInFlight = false
+	m.LastSaveErr = msg.Err
+	if msg.Err != nil {
+		// Try again on the next autosave
+		m.Dirty = true
+		return m, nil
+	}
+	m.LastSaveTime = msg.Time
+	return m, nil
+}
+
+// Save saves synchronously and records the result like an autosave
+func (m *Model) Save() error {
+	m.captureResumeState()
+	err := m.SaveManager.Save(m.Player)
+	m.LastSaveErr = err
+	if err == nil {
+		m.LastSaveTime = m.Player.LastOnline
+		m.Dirty = false
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BackupPreview is a loaded backup shown on the restore screen
+type BackupPreview struct {
+	Player *models.Player
+	Err    error
+}
+
+// RestoreState tracks the backup restore screen
+type RestoreState struct {
+	Backups          []data.BackupInfo
+	Previews         map[string]*BackupPreview
+	ShowConfirmation bool
+}
+
+// openRestoreScreen lists the backups of the current save
+func (m *Model) openRestoreScreen() (*Model, tea.Cmd) {
+	m.RestoreState = RestoreState{
+		Backups:  m.SaveManager.ListBackups(),
+		Previews: make(map[string]*BackupPreview),
+	}
+	m.State = StateBackupRestore
+	m.CursorPosition = 0
+	return m, nil
+}
+
+// SelectedBackupPreview loads, once, the backup under the cursor
+func (m *Model) SelectedBackupPreview() (*data.BackupInfo, *BackupPreview) {
+	if m.CursorPosition >= len(m.RestoreState.Backups) {
+		return nil, nil
+	}
+	backup := &m.RestoreState.Backups[m.CursorPosition]
+
+	preview, ok := m.RestoreState.Previews[backup.Path]
+	if !ok {
+		player, err := data.LoadFile(backup.Path)
+		preview = &BackupPreview{Player: player, Err: err}
+		m.RestoreState.Previews[backup.Path] = preview
+	}
+	return backup, preview
+}
+
+// handleRestoreInput handles the backup restore screen
+func (m *Model) handleRestoreInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	if m.RestoreState.ShowConfirmation {
+		switch msg.String() {
+		case "y", "Y":
+			m.RestoreState.ShowConfirmation = false
+			return m.restoreSelectedBackup()
+		case "n", "N", "esc":
+			m.RestoreState.ShowConfirmation = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.CursorPosition > 0 {
+			m.CursorPosition--
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.CursorPosition < len(m.RestoreState.Backups)-1 {
+			m.CursorPosition++
+		}
+		return m, nil
+
+	case "enter":
+		if _, preview := m.SelectedBackupPreview(); preview != nil && preview.Err == nil {
+			m.RestoreState.ShowConfirmation = true
+		}
+		return m, nil
+
+	case "esc":
+		m.State = StateDashboard
+		m.CursorPosition = 0
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// restoreSelectedBackup replaces the current player with the selected backup
+func (m *Model) restoreSelectedBackup() (*Model, tea.Cmd) {
+	backup, preview := m.SelectedBackupPreview()
+	if preview == nil || preview.Err != nil {
+		return m, nil
+	}
+
+	// Load a fresh copy rather than the cached preview
+	player, err := data.LoadFile(backup.Path)
+	if err != nil {
+		m.CurrentMessage = fmt.Sprintf("Restore failed: %v", err)
+		m.ShowMessage = true
+		return m, hideMessageCmd(3 * time.Second)
+	}
+
+	err = m.replacePlayer(player,
+		fmt.Sprintf("Restored %s backup from %s", backup.Tier, backup.Time.Format("2006-01-02 15:04")),
+		map[string]interface{}{"backup": backup.Path})
+	if err != nil {
+		m.CurrentMessage = fmt.Sprintf("Restore failed: %v", err)
+	} else {
+		m.CurrentMessage = fmt.Sprintf("Restored backup from %s", backup.Time.Format("2006-01-02 15:04"))
+	}
+	m.ShowMessage = true
+	m.State = StateDashboard
+	m.CursorPosition = 0
+	return m, hideMessageCmd(3 * time.Second)
+}
+
+// replacePlayer swaps in a different player and saves it, logging why.
+// The current state is saved first, so it stays in the backups and the
+// swap itself can be undone from the restore screen.
+func (m *Model) replacePlayer(player *models.Player, logMessage string, details map[string]interface{}) error {
+	if err := m.Save(); err != nil {
+		return fmt.Errorf("could not save current game first: %w", err)
+	}
+
+	if m.Recorder != nil {
+		m.Recorder.PlayerReplaced(logMessage)
+	}
+	m.Player = player
+	m.Game.Player = player
+	m.Game.Fight = nil
+	ResetInventoryState(&m.InventoryState)
+
+	if m.Player.ActivityLog == nil {
+		m.Player.ActivityLog = models.NewActivityLog()
+	}
+	m.Player.ActivityLog.AddEntry(models.LogTypeSystem, logMessage, details)
+
+	m.Dirty = true
+	if err := m.Save(); err != nil {
+		return fmt.Errorf("replaced, but save failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxConsoleTicks caps one tick command, so a typo can't hang the game
+const maxConsoleTicks = 7 * 24 * 60 * 60
+
+// maxConsoleGold caps the gold command, well short of overflowing
+const maxConsoleGold = 1e15
+
+// consoleHelp lists the console commands
+const consoleHelp = "give <item> [qty], level <skill> <n>, attr <attribute> <n>, gold <n>, start <activity>, fight <monster>, tick <n>, speed <1|10|100>, ff <hours>"
+
+// ConsoleState tracks the developer console, opened with ":" when the
+// game runs with --dev
+type ConsoleState struct {
+	Open    bool
+	Input   string
+	History []string // Commands run this session, oldest first
+	recall  int      // Position in History while browsing with up and down
+}
+
+// openConsole opens the console with an empty command line
+func (m *Model) openConsole() (*Model, tea.Cmd) {
+	m.Console.Open = true
+	m.Console.Input = ""
+	m.Console.recall = len(m.Console.History)
+	return m, nil
+}
+
+// handleConsoleInput edits and runs the console command line. Every key is
+// text while it is open, so it is routed here before the global shortcuts.
+func (m *Model) handleConsoleInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	console := &m.Console
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.Save()
+		return m, tea.Quit
+
+	case tea.KeyEsc:
+		console.Open = false
+		return m, nil
+
+	case tea.KeyEnter:
+		line := strings.TrimSpace(console.Input)
+		console.Open = false
+		if line == "" {
+			return m, nil
+		}
+		console.History = append(console.History, line)
+		return m.runConsoleLine(line)
+
+	case tea.KeyBackspace:
+		if len(console.Input) > 0 {
+			console.Input = console.Input[:len(console.Input)-1]
+		}
+
+	case tea.KeyUp:
+		if console.recall > 0 {
+			console.recall--
+			console.Input = console.History[console.recall]
+		}
+
+	case tea.KeyDown:
+		if console.recall < len(console.History)-1 {
+			console.recall++
+			console.Input = console.History[console.recall]
+		} else {
+			console.recall = len(console.History)
+			console.Input = ""
+		}
+
+	case tea.KeySpace:
+		console.Input += " "
+
+	case tea.KeyRunes:
+		console.Input += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// runConsoleLine runs a console command, writes it to the activity log and
+// shows its result
+func (m *Model) runConsoleLine(line string) (*Model, tea.Cmd) {
+	m.Dirty = true
+	m.log().AddEntry(models.LogTypeSystem, "Console: "+line, map[string]interface{}{"command": line})
+
+	result, cmd, err := m.runConsoleCommand(line)
+	if err != nil {
+		m.log().AddEntry(models.LogTypeSystem, fmt.Sprintf("Console: %v", err), map[string]interface{}{
+			"command": line,
+			"error":   err.Error(),
+		})
+		m.notify(err.Error())
+		return m, hideMessageCmd(3 * time.Second)
+	}
+	m.notify(result)
+	return m, tea.Batch(cmd, hideMessageCmd(2*time.Second))
+}
+
+// runConsoleCommand runs one console command through the same game and
+// engine calls as normal play, and returns what it did and any command
+// it started
+func (m *Model) runConsoleCommand(line string) (string, tea.Cmd, error) {
+	fields := strings.Fields(line)
+	command, args := strings.ToLower(fields[0]), fields[1:]
+
+	switch command {
+	case "give":
+		if len(args) < 1 || len(args) > 2 {
+			return "", nil, fmt.Errorf("usage: give <item> [qty]")
+		}
+		qty := int64(1)
+		if len(args) == 2 {
+			var err error
+			if qty, err = parseConsoleAmount(args[1], math.MaxInt32); err != nil {
+				return "", nil, err
+			}
+		}
+		haul, err := m.Game.Give(args[0], int(qty))
+		if err != nil {
+			return "", nil, err
+		}
+		if haul.Full() {
+			return fmt.Sprintf("Gave %dx %s (inventory full, see the log)", qty, models.ItemName(args[0])), nil, nil
+		}
+		return fmt.Sprintf("Gave %dx %s", qty, models.ItemName(args[0])), nil, nil
+
+	case "level":
+		if len(args) != 2 {
+			return "", nil, fmt.Errorf("usage: level <skill> <n>")
+		}
+		level, err := parseConsoleAmount(args[1], math.MaxInt32)
+		if err != nil {
+			return "", nil, err
+		}
+		skill := models.SkillType(strings.ToLower(args[0]))
+		if err := m.Game.SetLevel(skill, int(level)); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s set to level %d", models.SkillNames[skill], level), nil, nil
+
+	case "attr":
+		if len(args) != 2 {
+			return "", nil, fmt.Errorf("usage: attr <attribute> <n>")
+		}
+		level, err := parseConsoleAmount(args[1], math.MaxInt32)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := m.Game.SetAttribute(args[0], int(level)); err != nil {
+			return "", nil, err
+		}
+		name := strings.ToLower(args[0])
+		return fmt.Sprintf("%s set to level %d", strings.ToUpper(name[:1])+name[1:], level), nil, nil
+
+	case "gold":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: gold <n>")
+		}
+		gold, err := parseConsoleAmount(args[0], maxConsoleGold)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := m.Game.SetGold(gold); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("Gold set to %s", models.FormatNumber(gold)), nil, nil
+
+	case "start":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: start <activity>")
+		}
+		// startActivity shows its own message and leaves the activity
+		// alone if it fails
+		before := m.Player.CurrentActivity
+		_, cmd := m.startActivity(args[0])
+		if m.Player.CurrentActivity == before {
+			return "", nil, errors.New(m.CurrentMessage)
+		}
+		return m.CurrentMessage, cmd, nil
+
+	case "fight":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: fight <monster>")
+		}
+		before := m.Game.Fight
+		_, cmd := m.startCombat(args[0])
+		if m.Game.Fight == before {
+			return "", nil, errors.New(m.CurrentMessage)
+		}
+		return m.CurrentMessage, cmd, nil
+
+	case "tick":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: tick <n>")
+		}
+		n, err := parseConsoleAmount(args[0], maxConsoleTicks)
+		if err != nil {
+			return "", nil, err
+		}
+		m.processTicks(int(n))
+		return fmt.Sprintf("Advanced %s ticks", models.FormatNumber(n)), nil, nil
+
+	case "speed":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: speed <1|10|100>")
+		}
+		scale, err := parseConsoleAmount(strings.TrimSuffix(args[0], "x"), math.MaxInt32)
+		if err != nil {
+			return "", nil, err
+		}
+		if err := m.SetTimeScale(int(scale)); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("Time scale: %dx", scale), nil, nil
+
+	case "ff":
+		if len(args) != 1 {
+			return "", nil, fmt.Errorf("usage: ff <hours>")
+		}
+		hours, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%q is not a number of hours", args[0])
+		}
+		_, cmd, err := m.startFastForward(time.Duration(hours * float64(time.Hour)))
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("Fast-forwarding %s...", models.FormatDuration(m.FastForward.Duration)), cmd, nil
+
+	case "help":
+		return consoleHelp, nil, nil
+	}
+	return "", nil, fmt.Errorf("unknown command %q (%s)", command, consoleHelp)
+}
+
+// parseConsoleAmount parses a whole, non-negative number up to max. It
+// accepts exponents, so "1e6" is a million.
+func parseConsoleAmount(s string, max int64) (int64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%q is not a whole number", s)
+	}
+	if f > float64(max) {
+		return 0, fmt.Errorf("%s is more than %d", s, max)
+	}
+	return int64(f), nil
+}
+
+// log returns the player's activity log, creating it if needed
+func (m *Model) log() *models.ActivityLog {
+	if m.Player.ActivityLog == nil {
+		m.Player.ActivityLog = models.NewActivityLog()
+	}
+	return m.Player.ActivityLog
+}
@@ -2,11 +2,14 @@ package engine
 
 import (
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"afk-tui/internal/config"
 	"afk-tui/internal/data"
+	"afk-tui/internal/game"
 	"afk-tui/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -29,6 +32,10 @@ const (
 	StateSlayerMonsterSelection
 	StateCharacterSheet
 	StateNameEdit
+	StateRecovery
+	StateBackupRestore
+	StateSaveCode
+	StateWelcomeBack
 )
 
 // ActivityCategory represents a group of activities
@@ -51,22 +58,11 @@ type ActivityOption struct {
 	Output      string
 }
 
-// CombatEncounter tracks an active combat session
-type CombatEncounter struct {
-	Monster          *models.Monster
-	PlayerATB        float64 // 0-100
-	MonsterATB       float64 // 0-100
-	IsPlayerTurn     bool
-	CombatTicks      int
-	DamageDealt      int
-	DamageTaken      int
-	LastActionResult string
-}
-
 // Model is the main game model for Bubble Tea
 type Model struct {
 	State            GameState
 	Player           *models.Player
+	Game             *game.Game // Game rules; Game.Player is Player
 	SaveManager      *data.SaveManager
 	OfflineProcessor *data.OfflineProcessor
 
@@ -85,109 +81,175 @@ type Model struct {
 	LogEntriesPerPage int
 
 	// Combat & Training state
-	SelectedTrainingType   string // strength, dexterity, defense
-	SelectedSlayerTier     int    // 1-5 for monster tiers
-	SelectedMonsterID      string
-	CurrentCombatEncounter *CombatEncounter
-	NameEditBuffer         string
-	NameEditCursor         int
+	SelectedTrainingType string // strength, dexterity, defense
+	SelectedSlayerTier   int    // 1-5 for monster tiers
+	SelectedMonsterID    string
+	NameEditBuffer       string
+	NameEditCursor       int
 
 	// Inventory state
 	InventoryState InventoryState
 
+	// Save recovery and restore state
+	RestoreState RestoreState
+	SaveCode     SaveCodeState
+	WelcomeBack  WelcomeBackState
+	Recovery     *data.RecoveryReport
+	SaveOnExit   bool // False when the player declined to start over
+
+	// Autosave
+	AutosaveInterval time.Duration
+	Dirty            bool // Something changed since the last save
+	SaveInFlight     bool
+	LastSaveTime     time.Time
+	LastSaveErr      error
+
 	// Ticks
-	TickRate  time.Duration
-	LastTick  time.Time
-	TickCount int // For animation
+	TickRate    time.Duration
+	LastTick    time.Time
+	TickCount   int // For animation
+	TimeScale   int // Ticks per TickRate; 1 is real time
+	FastForward FastForwardState
+
+	// UI preferences
+	Animations bool
+
+	// Developer console, only with --dev
+	DevMode bool
+	Console ConsoleState
+
+	// Recorder records the session for replay, if set
+	Recorder *Recorder
 
 	// Views
 	Width  int
 	Height int
 }
 
-// NewModel creates a new game model
-func NewModel(player *models.Player, saveManager *data.SaveManager) *Model {
-	return &Model{
+// NewModel creates a new game model. A nil settings uses config.Default().
+func NewModel(player *models.Player, saveManager *data.SaveManager, settings *config.Settings) *Model {
+	if settings == nil {
+		settings = config.Default()
+	}
+
+	offlineProcessor := data.NewOfflineProcessor()
+	offlineProcessor.MaxOfflineTime = time.Duration(settings.OfflineCap)
+	offlineProcessor.TickRate = time.Duration(settings.TickRate)
+
+	m := &Model{
 		State:             StateDashboard,
 		Player:            player,
 		SaveManager:       saveManager,
-		OfflineProcessor:  data.NewOfflineProcessor(),
+		OfflineProcessor:  offlineProcessor,
 		SelectedSkill:     models.SkillWoodcutting,
-		TickRate:          1 * time.Second,
+		TickRate:          time.Duration(settings.TickRate),
+		TimeScale:         1,
+		AutosaveInterval:  time.Duration(settings.AutosaveInterval),
 		LastTick:          time.Now(),
 		CursorPosition:    0,
 		LogViewExpanded:   false,
 		LogScrollPosition: 0,
-		LogEntriesPerPage: 20,
+		LogEntriesPerPage: settings.UI.LogEntriesPerPage,
 		TickCount:         0,
+		Animations:        settings.UI.Animations,
+		SaveOnExit:        true,
+		Game:              game.NewGame(player, models.NewSeed()),
 	}
+	// Offline progress draws from the game's randomness too
+	offlineProcessor.Rand = m.Game.Rand
+	m.subscribeNotifications()
+	m.applyResumeState()
+	return m
 }
 
-// Init initializes the model
-func (m *Model) Init() tea.Cmd {
-	// Process offline progress
-	result := m.OfflineProcessor.CalculateOfflineProgress(m.Player)
-	if result.OfflineTime > 0 {
-		// Log offline progress to activity log instead of showing popup
-		if m.Player.ActivityLog == nil {
-			m.Player.ActivityLog = models.NewActivityLog()
-		}
+// SetSeed replaces the session's random seed, e.g. to reproduce a session
+// from a bug report. It must be called before Init.
+func (m *Model) SetSeed(seed int64) {
+	m.Game.Reseed(seed)
+}
 
-		// Format resume-style summary
-		hours := int(result.OfflineTime.Hours())
-		minutes := int(result.OfflineTime.Minutes()) % 60
+// ShowRecoveryReport opens the recovery screen for a damaged save
+func (m *Model) ShowRecoveryReport(report *data.RecoveryReport) {
+	m.Recovery = report
+	m.State = StateRecovery
 
-		var timeStr string
-		if hours > 0 {
-			timeStr = fmt.Sprintf("%dh %dm", hours, minutes)
-		} else {
-			timeStr = fmt.Sprintf("%dm", minutes)
+	if report.Recovered() {
+		if m.Player.ActivityLog == nil {
+			m.Player.ActivityLog = models.NewActivityLog()
 		}
+		m.Player.ActivityLog.AddEntry(models.LogTypeSystem,
+			fmt.Sprintf("Save recovered from %s", filepath.Base(report.RecoveredFrom)),
+			map[string]interface{}{
+				"recovered_from": report.RecoveredFrom,
+				"quarantined":    report.QuarantinedPath,
+			})
+	}
+}
 
-		// Create concise resume entry
-		resumeMsg := fmt.Sprintf("Away for %s: %d actions, %s XP gained",
-			timeStr, result.ActionsCompleted, formatNumber(result.XPGained))
+// Init initializes the model
+func (m *Model) Init() tea.Cmd {
+	// Record the seed, so the session can be replayed
+	if m.Player.ActivityLog == nil {
+		m.Player.ActivityLog = models.NewActivityLog()
+	}
+	m.Player.ActivityLog.AddEntry(models.LogTypeSystem, fmt.Sprintf("Session started (seed %d)", m.Game.Seed),
+		map[string]interface{}{"seed": m.Game.Seed})
+	m.Dirty = true
 
-		m.Player.ActivityLog.AddEntry(models.LogTypeSystem, resumeMsg, map[string]interface{}{
-			"offline_time":   result.OfflineTime.String(),
-			"actions":        result.ActionsCompleted,
-			"xp_gained":      result.XPGained,
-			"activity":       result.ActivityName,
-			"skill":          result.SkillName,
-			"items_gained":   result.ItemsGained,
-			"perks_unlocked": len(result.PerksUnlocked),
-		})
+	// Process offline progress. What happened while away is published like
+	// online play, so the log and notifications pick it up.
+	result := m.OfflineProcessor.CalculateOfflineProgress(m.Player)
+	for _, event := range result.Events(m.Player) {
+		m.Game.Events.Publish(event)
+	}
+	var hideCmd tea.Cmd
+	if result.ClockWarning != "" {
+		m.CurrentMessage = "Offline progress skipped: the system clock changed"
+		m.ShowMessage = true
+	}
+	if m.ShowMessage {
+		hideCmd = hideMessageCmd(3 * time.Second)
+	}
 
-		// Also log item summary if any items were gained
-		if len(result.ItemsGained) > 0 {
-			var itemSummary []string
-			for itemID, qty := range result.ItemsGained {
-				item := models.GetItemTemplate(itemID)
-				name := itemID
-				if item != nil {
-					name = item.Name
-				}
-				itemSummary = append(itemSummary, fmt.Sprintf("%d %s", qty, name))
-			}
-			if len(itemSummary) > 0 {
-				itemsMsg := "Items: " + strings.Join(itemSummary, ", ")
-				m.Player.ActivityLog.AddEntry(models.LogTypeItem, itemsMsg, nil)
-			}
-		}
+	// Pick the fight up where it was left
+	if result.InCombat {
+		m.Game.Fight = nil
+		m.applyResumeState()
+	}
 
-		// Log perks if any were unlocked
-		for _, perk := range result.PerksUnlocked {
-			m.Player.ActivityLog.AddPerkLog(perk.Name, result.SkillType)
+	// Report a meaningful absence in full, after any recovery screen
+	if result.OfflineTime >= welcomeBackMinAbsence && result.TicksProcessed > 0 {
+		m.WelcomeBack.Report = result
+		if m.State != StateRecovery {
+			m.openWelcomeBack()
 		}
 	}
 
-	return tickCmd(m.TickRate)
+	return tea.Batch(tickCmd(m.TickRate), autosaveCmd(m.AutosaveInterval), hideCmd)
 }
 
 // Update handles messages
 func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	if m.Recorder != nil {
+		m.Recorder.Record(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The recovery screen must be answered before anything else
+		if m.State == StateRecovery {
+			return m.handleRecoveryInput(msg)
+		}
+		// A pasted save code must not trigger shortcuts
+		if m.State == StateSaveCode {
+			return m.handleSaveCodeInput(msg)
+		}
+		if m.State == StateWelcomeBack {
+			return m.handleWelcomeBackInput(msg)
+		}
+		if m.Console.Open {
+			return m.handleConsoleInput(msg)
+		}
 		// Handle log view scrolling first if in log view mode
 		if m.LogViewExpanded {
 			return m.handleLogViewInput(msg)
@@ -200,10 +262,22 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		return m, nil
 
 	case TickMsg:
-		m.processTick()
+		if m.Player.CurrentActivity != nil || m.Game.Fight != nil {
+			m.Dirty = true
+		}
+		m.runScaledTicks()
 		m.TickCount++
 		return m, tickCmd(m.TickRate)
 
+	case FastForwardMsg:
+		return m.handleFastForward()
+
+	case AutosaveMsg:
+		return m.handleAutosave()
+
+	case SaveResultMsg:
+		return m.handleSaveResult(msg)
+
 	case HideMessageMsg:
 		m.ShowMessage = false
 		return m, nil
@@ -212,6 +286,45 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleRecoveryInput handles the damaged-save screen
+func (m *Model) handleRecoveryInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	if m.Recovery.Recovered() {
+		switch msg.String() {
+		case "enter", "esc", " ":
+			m.State = StateDashboard
+			m.Recovery = nil
+			if m.WelcomeBack.Report != nil {
+				m.openWelcomeBack()
+			}
+		case "ctrl+c":
+			m.Save()
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	// Nothing was recovered: only start over on an explicit yes
+	switch msg.String() {
+	case "y", "Y":
+		if m.Player.ActivityLog == nil {
+			m.Player.ActivityLog = models.NewActivityLog()
+		}
+		m.Player.ActivityLog.AddEntry(models.LogTypeSystem, "Started a new game after save recovery failed", map[string]interface{}{
+			"quarantined": m.Recovery.QuarantinedPath,
+		})
+		m.State = StateDashboard
+		m.Recovery = nil
+		m.Dirty = true
+		return m, nil
+
+	case "n", "N", "q", "esc", "ctrl+c":
+		m.SaveOnExit = false
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
 // handleLogViewInput handles input when log view is expanded
 func (m *Model) handleLogViewInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	switch msg.String() {
@@ -295,7 +408,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	// Global shortcuts first
 	switch msg.String() {
 	case "ctrl+c":
-		m.SaveManager.Save(m.Player)
+		m.Save()
 		return m, tea.Quit
 
 	case "?", "h":
@@ -349,7 +462,7 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		return m, nil
 
 	case "ctrl+s":
-		if err := m.SaveManager.Save(m.Player); err != nil {
+		if err := m.Save(); err != nil {
 			m.CurrentMessage = fmt.Sprintf("Save failed: %v", err)
 		} else {
 			m.CurrentMessage = "Game saved!"
@@ -357,10 +470,26 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		m.ShowMessage = true
 		return m, hideMessageCmd(2 * time.Second)
 
+	case "ctrl+r":
+		return m.openRestoreScreen()
+
+	case "ctrl+e":
+		return m.openSaveCodeScreen()
+
+	case ":":
+		if m.DevMode && m.State != StateNameEdit {
+			return m.openConsole()
+		}
+
+	case ">":
+		if m.DevMode && m.State != StateNameEdit {
+			return m.cycleTimeScale()
+		}
+
 	case "q":
 		// Only quit if not in a menu
 		if m.State == StateDashboard {
-			m.SaveManager.Save(m.Player)
+			m.Save()
 			return m, tea.Quit
 		}
 		m.State = StateDashboard
@@ -389,6 +518,8 @@ func (m *Model) handleKeyPress(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		return m.handleCharacterSheetInput(msg)
 	case StateNameEdit:
 		return m.handleNameEditInput(msg)
+	case StateBackupRestore:
+		return m.handleRestoreInput(msg)
 	}
 
 	return m, nil
@@ -607,7 +738,10 @@ func (m *Model) handleInventoryInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 
 	// Handle sell mode input
 	if m.InventoryState.IsSellMode {
-		message, completed, _ := HandleInventoryInput(msgStr, &m.InventoryState, m.Player)
+		message, completed, gold := HandleInventoryInput(msgStr, &m.InventoryState, m.Game)
+		if gold > 0 {
+			m.Dirty = true
+		}
 
 		if message != "" {
 			m.CurrentMessage = message
@@ -633,6 +767,20 @@ func (m *Model) handleInventoryInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		m.CurrentMessage = "Sell mode: Enter item number"
 		m.ShowMessage = true
 		return m, hideMessageCmd(3 * time.Second)
+
+	case "f":
+		// Cycle what happens to items when the inventory is full
+		policy := m.Player.Overflow().Next()
+		m.Player.OverflowPolicy = policy
+		m.Dirty = true
+		if m.Player.ActivityLog == nil {
+			m.Player.ActivityLog = models.NewActivityLog()
+		}
+		m.Player.ActivityLog.AddEntry(models.LogTypeSystem, fmt.Sprintf("When the inventory is full: %s", policy.Description()),
+			map[string]interface{}{"overflow": policy})
+		m.CurrentMessage = fmt.Sprintf("When full: %s", policy.Description())
+		m.ShowMessage = true
+		return m, hideMessageCmd(2 * time.Second)
 	}
 
 	// Handle sell mode special keys
@@ -857,9 +1005,8 @@ func (m *Model) handleSlayerMonsterInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 			}
 			for i, monster := range monsters {
 				if len(monster.Name) > 0 && strings.ToLower(monster.Name)[0] == char {
-					return m.startCombat(monster.ID)
 					m.CursorPosition = i
-					return m, nil
+					return m.startCombat(monster.ID)
 				}
 			}
 		}
@@ -873,7 +1020,10 @@ func (m *Model) handleActiveCombatInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc", "q":
 		// Flee combat
-		m.CurrentCombatEncounter = nil
+		if m.Game.Flee() == nil {
+			m.Dirty = true
+		}
+		m.SelectedMonsterID = ""
 		m.State = StateSlayerMonsterSelection
 		m.CurrentMessage = "You fled from combat!"
 		m.ShowMessage = true
@@ -890,6 +1040,9 @@ func (m *Model) handleCharacterSheetInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		m.State = StateDashboard
 		return m, nil
 
+	case "o":
+		return m.buyOfflineCapUpgrade()
+
 	case "n":
 		// Start name editing
 		m.State = StateNameEdit
@@ -901,6 +1054,33 @@ func (m *Model) handleCharacterSheetInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
+// buyOfflineCapUpgrade spends gold to extend the offline cap
+func (m *Model) buyOfflineCapUpgrade() (*Model, tea.Cmd) {
+	cost, err := m.Player.BuyOfflineCapUpgrade()
+	if err != nil {
+		m.CurrentMessage = err.Error()
+		m.ShowMessage = true
+		return m, hideMessageCmd(2 * time.Second)
+	}
+
+	m.Dirty = true
+	limit := m.OfflineProcessor.CapFor(m.Player)
+	if m.Player.ActivityLog == nil {
+		m.Player.ActivityLog = models.NewActivityLog()
+	}
+	m.Player.ActivityLog.AddEntry(models.LogTypeSystem,
+		fmt.Sprintf("Offline cap upgraded to %s for %s gold", models.FormatDuration(limit), models.FormatNumber(cost)),
+		map[string]interface{}{
+			"upgrades": m.Player.OfflineCapUpgrades,
+			"cost":     cost,
+			"cap":      limit.String(),
+		})
+
+	m.CurrentMessage = fmt.Sprintf("Offline cap is now %s!", models.FormatDuration(limit))
+	m.ShowMessage = true
+	return m, hideMessageCmd(2 * time.Second)
+}
+
 // handleNameEditInput handles name editing mode
 func (m *Model) handleNameEditInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 	switch msg.Type {
@@ -913,6 +1093,7 @@ func (m *Model) handleNameEditInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
 		// Save new name
 		if len(m.NameEditBuffer) > 0 && len(m.NameEditBuffer) <= 20 {
 			m.Player.Name = m.NameEditBuffer
+			m.Dirty = true
 			m.CurrentMessage = fmt.Sprintf("Name changed to: %s", m.Player.Name)
 		} else {
 			m.CurrentMessage = "Name must be 1-20 characters"
@@ -985,35 +1166,22 @@ func getMonstersForTier(tier int) []*models.Monster {
 
 // startTraining starts a training activity
 func (m *Model) startTraining(trainingID string) (*Model, tea.Cmd) {
-	// Check if training activity exists
-	if _, ok := models.ActivityDatabase[trainingID]; !ok {
-		m.CurrentMessage = fmt.Sprintf("Unknown training: %s", trainingID)
-		m.ShowMessage = true
-		return m, hideMessageCmd(2 * time.Second)
-	}
-
 	return m.startActivity(trainingID)
 }
 
 // startCombat starts combat with a monster
 func (m *Model) startCombat(monsterID string) (*Model, tea.Cmd) {
-	monster := models.Monsters.GetMonster(monsterID)
-	if monster == nil {
-		m.CurrentMessage = fmt.Sprintf("Unknown monster: %s", monsterID)
+	encounter, err := m.Game.StartCombat(monsterID)
+	if err != nil {
+		m.CurrentMessage = err.Error()
 		m.ShowMessage = true
 		return m, hideMessageCmd(2 * time.Second)
 	}
 
-	// Initialize combat encounter
-	m.CurrentCombatEncounter = &CombatEncounter{
-		Monster:      monster,
-		PlayerATB:    0,
-		MonsterATB:   0,
-		IsPlayerTurn: true,
-	}
+	m.Dirty = true
 	m.SelectedMonsterID = monsterID
 	m.State = StateCombat
-	m.CurrentMessage = fmt.Sprintf("Combat started: %s!", monster.Name)
+	m.CurrentMessage = fmt.Sprintf("Combat started: %s!", encounter.Monster.Name)
 	m.ShowMessage = true
 
 	return m, hideMessageCmd(2 * time.Second)
@@ -1021,32 +1189,15 @@ func (m *Model) startCombat(monsterID string) (*Model, tea.Cmd) {
 
 // startActivity starts a new activity
 func (m *Model) startActivity(activityID string) (*Model, tea.Cmd) {
-	activity := models.NewActivity(activityID)
-	if activity == nil {
-		m.CurrentMessage = fmt.Sprintf("Unknown activity: %s", activityID)
-		m.ShowMessage = true
-		return m, hideMessageCmd(2 * time.Second)
-	}
-
-	// Check requirements
-	if err := activity.CanDo(m.Player); err != nil {
+	activity, err := m.Game.StartActivity(activityID)
+	if err != nil {
 		m.CurrentMessage = err.Error()
 		m.ShowMessage = true
 		return m, hideMessageCmd(3 * time.Second)
 	}
-
-	// Apply modifiers
-	activity.ApplyModifiers(m.Player)
-
-	// Set as current activity
-	m.Player.CurrentActivity = activity
+	m.Dirty = true
 	m.SelectedActivity = activityID
-
-	// Log activity start
-	if m.Player.ActivityLog == nil {
-		m.Player.ActivityLog = models.NewActivityLog()
-	}
-	m.Player.ActivityLog.AddActivityLog(activity.Name, true)
+	m.SelectedMonsterID = "" // Stops offline time going to the last monster fought
 
 	m.CurrentMessage = fmt.Sprintf("Started: %s", activity.Name)
 	m.ShowMessage = true
@@ -1057,347 +1208,16 @@ func (m *Model) startActivity(activityID string) (*Model, tea.Cmd) {
 	return m, hideMessageCmd(2 * time.Second)
 }
 
-// processTick handles game tick logic
-func (m *Model) processTick() {
-	// Process active combat first
-	if m.State == StateCombat && m.CurrentCombatEncounter != nil {
-		m.processCombatTick()
-		return
-	}
-
-	if m.Player.CurrentActivity == nil {
-		return
-	}
-
-	activity := m.Player.CurrentActivity
-	activity.ApplyModifiers(m.Player)
-
-	// Handle training activities specially
-	if activity.ID == "strength_training" || activity.ID == "dexterity_training" || activity.ID == "defense_training" {
-		m.processTrainingTick(activity)
-		return
-	}
-
-	// Consume required items for crafting
-	if activity.Type == models.ActivityCrafting || activity.Type == models.ActivityRecycling {
-		if activity.Progress == 0 {
-			for itemID, qty := range activity.RequiredItems {
-				if !m.Player.Inventory.HasItem(itemID, qty) {
-					m.Player.CurrentActivity = nil
-					if m.Player.ActivityLog == nil {
-						m.Player.ActivityLog = models.NewActivityLog()
-					}
-					m.Player.ActivityLog.AddActivityLog(activity.Name, false)
-					m.CurrentMessage = fmt.Sprintf("Ran out of %s", itemID)
-					m.ShowMessage = true
-					return
-				}
-			}
-
-			// Consume items
-			for itemID, qty := range activity.RequiredItems {
-				m.Player.Inventory.RemoveItem(itemID, qty)
-			}
-		}
-	}
-
-	// Process tick
-	completed := activity.Tick()
-
-	if completed {
-		// Action completed!
-		xpGained := activity.GetXP()
-		outputItems := activity.GetOutput()
-
-		skill := m.Player.GetSkill(activity.SkillType)
-		oldLevel := skill.Level
-
-		// Add XP
-		perks := m.Player.AddXP(activity.SkillType, xpGained)
-
-		// Start combined XP log
-		if m.Player.ActivityLog == nil {
-			m.Player.ActivityLog = models.NewActivityLog()
-		}
-		m.Player.ActivityLog.StartXPEntry(activity.SkillType, xpGained, skill.Level)
-
-		// Add items to inventory and combine with XP log
-		for itemID, qty := range outputItems {
-			item := models.NewItem(itemID, "", qty)
-			if m.Player.Inventory.AddItem(item) {
-				itemTemplate := models.GetItemTemplate(itemID)
-				if itemTemplate != nil {
-					m.Player.ActivityLog.AddItemToPending(itemTemplate.Name, qty)
-				}
-			} else {
-				m.CurrentMessage = fmt.Sprintf("Inventory full! Dropped %s", item.Name)
-				m.ShowMessage = true
-			}
-		}
-
-		// Finalize XP log (if no items were added, this just logs XP)
-		m.Player.ActivityLog.FinalizePendingXP()
-
-		// Check for level up
-		if skill.Level > oldLevel {
-			if m.Player.ActivityLog == nil {
-				m.Player.ActivityLog = models.NewActivityLog()
-			}
-			m.Player.ActivityLog.AddLevelUpLog(activity.SkillType, skill.Level)
-		}
-
-		// Check for new perks
-		if len(perks) > 0 {
-			for _, perk := range perks {
-				if m.Player.ActivityLog == nil {
-					m.Player.ActivityLog = models.NewActivityLog()
-				}
-				m.Player.ActivityLog.AddPerkLog(perk.Name, activity.SkillType)
-				m.CurrentMessage = fmt.Sprintf("Perk Unlocked: %s!", perk.Name)
-				m.ShowMessage = true
-			}
-		}
-
-		// Reset for next action
-		activity.Reset()
-	}
-
-	m.LastTick = time.Now()
-}
-
-// processCombatTick handles combat logic per tick
-func (m *Model) processCombatTick() {
-	if m.CurrentCombatEncounter == nil {
-		return
-	}
-
-	encounter := m.CurrentCombatEncounter
-	player := m.Player
-
-	// Fill ATB bars based on speed
-	playerSpeed := models.GetATBFill(1, player.Attributes.Dexterity.Level)
-	monsterSpeed := encounter.Monster.Speed * 5 // Convert speed to ATB fill rate
-
-	encounter.PlayerATB += playerSpeed
-	encounter.MonsterATB += monsterSpeed
-
-	// Process player turn
-	if encounter.PlayerATB >= 100 {
-		encounter.PlayerATB = 0
-		encounter.IsPlayerTurn = true
-
-		// Calculate damage
-		damage := models.CalculateDamage(
-			player.CombatStats.Attack,
-			player.Attributes.Strength.Level,
-			encounter.Monster.Defense,
-			models.CombatStyleMelee,
-			encounter.Monster.Weakness,
-			encounter.Monster.Resistance,
-		)
-
-		if damage > 0 {
-			encounter.Monster.Hitpoints -= damage
-			encounter.DamageDealt += damage
-			encounter.LastActionResult = fmt.Sprintf("You hit %s for %d damage!", encounter.Monster.Name, damage)
-
-			// Check if monster defeated
-			if encounter.Monster.Hitpoints <= 0 {
-				// Monster defeated!
-				encounter.Monster.Hitpoints = 0
-				m.completeCombat(encounter)
-				return
-			}
-		} else {
-			encounter.LastActionResult = fmt.Sprintf("You missed %s!", encounter.Monster.Name)
-		}
-	}
-
-	// Process monster turn
-	if encounter.MonsterATB >= 100 {
-		encounter.MonsterATB = 0
-		encounter.IsPlayerTurn = false
-
-		// Calculate monster damage
-		damage := models.CalculateDamage(
-			encounter.Monster.Attack,
-			encounter.Monster.Strength,
-			player.Attributes.Defense.Level,
-			models.CombatStyleMelee,
-			"",
-			"",
-		)
-
-		if damage > 0 {
-			player.CombatStats.Hitpoints -= damage
-			encounter.DamageTaken += damage
-			encounter.LastActionResult = fmt.Sprintf("%s hits you for %d damage!", encounter.Monster.Name, damage)
-
-			// Check if player defeated
-			if player.CombatStats.Hitpoints <= 0 {
-				player.CombatStats.Hitpoints = 0
-				m.handlePlayerDefeat()
-				return
-			}
-		} else {
-			encounter.LastActionResult = fmt.Sprintf("%s missed you!", encounter.Monster.Name)
-		}
-	}
-
-	encounter.CombatTicks++
-	m.LastTick = time.Now()
-}
-
-// completeCombat handles monster defeat rewards
-func (m *Model) completeCombat(encounter *CombatEncounter) {
-	monster := encounter.Monster
-	player := m.Player
-
-	// Award XP
-	combatXP := monster.CombatXP
-	slayerXP := monster.SlayerXP
-
-	// Add Combat skill XP
-	m.Player.AddXP(models.SkillCombat, combatXP)
-
-	// Add Slayer XP (directly to combat stats)
-	player.CombatStats.SlayerXP += slayerXP
-	// Check for slayer level up
-	for player.CombatStats.SlayerXP >= models.CalculateXPToNext(player.CombatStats.SlayerLevel) && player.CombatStats.SlayerLevel < 120 {
-		player.CombatStats.SlayerXP -= models.CalculateXPToNext(player.CombatStats.SlayerLevel)
-		player.CombatStats.SlayerLevel++
-	}
-
-	// Award gold
-	player.Gold += monster.Gold
-
-	// Process drops
-	dropMessages := []string{}
-	for _, drop := range monster.Drops {
-		// Roll for drop
-		if drop.AlwaysDrop || (models.RollDrop(drop.DropRate)) {
-			item := models.NewItem(drop.ItemID, drop.ItemName, drop.Quantity)
-			if player.Inventory.AddItem(item) {
-				dropMessages = append(dropMessages, fmt.Sprintf("%dx %s", drop.Quantity, drop.ItemName))
-			}
-		}
-	}
-
-	// Log the kill
-	if player.ActivityLog == nil {
-		player.ActivityLog = models.NewActivityLog()
-	}
-	player.ActivityLog.AddActivityLog(fmt.Sprintf("Defeated %s", monster.Name), true)
-
-	// Show completion message
-	dropStr := ""
-	if len(dropMessages) > 0 {
-		dropStr = fmt.Sprintf(" Drops: %s", dropMessages)
-	}
-	m.CurrentMessage = fmt.Sprintf("Victory! +%s XP, +%s gold%s",
-		formatNumber(combatXP), formatNumber(monster.Gold), dropStr)
-	m.ShowMessage = true
-
-	// Reset monster HP for next fight
-	monster.Hitpoints = monster.MaxHP
-
-	// Return to monster selection
-	m.CurrentCombatEncounter = nil
-	m.State = StateSlayerMonsterSelection
-}
-
-// handlePlayerDefeat handles when player is defeated
-func (m *Model) handlePlayerDefeat() {
-	player := m.Player
-
-	// Reset player HP
-	player.CombatStats.Hitpoints = player.CombatStats.MaxHitpoints
-
-	// Clear current combat
-	m.CurrentCombatEncounter = nil
-
-	// Log the defeat
-	if player.ActivityLog == nil {
-		player.ActivityLog = models.NewActivityLog()
-	}
-	player.ActivityLog.AddActivityLog("Defeated in combat", false)
-
-	m.CurrentMessage = "You were defeated! HP restored."
-	m.ShowMessage = true
-	m.State = StateSlayerMonsterSelection
-}
-
-// processTrainingTick handles training activity progression
-func (m *Model) processTrainingTick(activity *models.Activity) {
-	player := m.Player
-
-	// Process the tick
-	completed := activity.Tick()
-
-	if completed {
-		xpGained := activity.GetXP()
-
-		// Add XP to appropriate attribute
-		switch activity.ID {
-		case "strength_training":
-			if player.Attributes.Strength.AddXP(xpGained) {
-				m.CurrentMessage = "Strength Level Up!"
-				m.ShowMessage = true
-			}
-		case "dexterity_training":
-			if player.Attributes.Dexterity.AddXP(xpGained) {
-				m.CurrentMessage = "Dexterity Level Up!"
-				m.ShowMessage = true
-			}
-		case "defense_training":
-			if player.Attributes.Defense.AddXP(xpGained) {
-				m.CurrentMessage = "Defense Level Up!"
-				m.ShowMessage = true
-			}
-		}
-
-		// Also add Combat skill XP
-		skill := player.GetSkill(models.SkillCombat)
-		oldLevel := skill.Level
-		player.AddXP(models.SkillCombat, xpGained/2) // Half XP to combat skill
-
-		if skill.Level > oldLevel {
-			if player.ActivityLog == nil {
-				player.ActivityLog = models.NewActivityLog()
-			}
-			player.ActivityLog.AddLevelUpLog(models.SkillCombat, skill.Level)
-		}
-
-		// Update derived stats
-		player.CombatStats.CalculateDerivedStats(player.Attributes)
-
-		// Log activity
-		if player.ActivityLog == nil {
-			player.ActivityLog = models.NewActivityLog()
-		}
-		player.ActivityLog.AddXPLog(models.SkillCombat, xpGained, skill.XP, skill.Level)
-
-		// Reset activity
-		activity.Reset()
-	}
+// processTicks advances the game by n ticks in one step. The notifications
+// subscribed to the game's events announce what happened.
+func (m *Model) processTicks(n int) {
+	// A fight only goes on while its screen is shown
+	m.Game.FightPaused = m.State != StateCombat
 
+	m.Game.Step(n)
 	m.LastTick = time.Now()
 }
 
-// formatNumber helper for combat messages
-func formatNumber(n int64) string {
-	if n >= 1000000000 {
-		return fmt.Sprintf("%.1fB", float64(n)/1000000000)
-	}
-	if n >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1000000)
-	}
-	if n >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
-	}
-	return fmt.Sprintf("%d", n)
-}
-
 // GetCategoriesForSkill returns categories for a skill
 func GetCategoriesForSkill(skill models.SkillType) []ActivityCategory {
 	switch skill {
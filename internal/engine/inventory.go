@@ -1,10 +1,11 @@
 package engine
 
 import (
-	"afk-tui/internal/models"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"afk-tui/internal/game"
 )
 
 // InventoryState tracks inventory view state
@@ -20,23 +21,22 @@ type InventoryState struct {
 }
 
 // HandleInventoryInput processes inventory key presses
-func HandleInventoryInput(msg string, state *InventoryState, player *models.Player) (string, bool, int64) {
+func HandleInventoryInput(msg string, state *InventoryState, g *game.Game) (string, bool, int64) {
+	player := g.Player
+
 	// If in sell mode with confirmation showing
 	if state.IsSellMode && state.ShowConfirmation {
 		switch msg {
 		case "y", "Y":
 			// Confirm sell
 			if state.ItemID != "" {
-				// Remove items
-				player.Inventory.RemoveItem(state.ItemID, state.QuantityToSell)
-				// Add gold
-				player.Gold += state.GoldValue
-				// Log it
-				player.ActivityLog.AddSellLog(state.ItemName, state.QuantityToSell, state.GoldValue)
-
-				message := fmt.Sprintf("Sold %dx %s for %d gold", state.QuantityToSell, state.ItemName, state.GoldValue)
+				quantity, name := state.QuantityToSell, state.ItemName
+				gold, err := g.Sell(state.ItemID, quantity)
 				ResetInventoryState(state)
-				return message, true, state.GoldValue
+				if err != nil {
+					return err.Error(), true, 0
+				}
+				return fmt.Sprintf("Sold %dx %s for %d gold", quantity, name, gold), true, gold
 			}
 		case "n", "N", "esc":
 			// Cancel
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"fmt"
+
+	"afk-tui/internal/game"
+	"afk-tui/internal/models"
+)
+
+// subscribeNotifications shows messages for the game's events and leaves
+// the combat screen when a fight ends
+func (m *Model) subscribeNotifications() {
+	events := m.Game.Events
+
+	game.On(events, func(e game.ActivityStopped) {
+		m.notify(fmt.Sprintf("%s! Stopped %s", e.Reason, e.Activity.Name))
+	})
+	game.On(events, func(e game.ItemsOverflowed) {
+		m.notify(overflowMessage(e))
+	})
+	game.On(events, func(e game.LevelUp) {
+		if e.Attribute != "" {
+			m.notify(fmt.Sprintf("%s Level Up!", e.Attribute))
+		}
+	})
+	game.On(events, func(e game.PerkUnlocked) {
+		m.notify(fmt.Sprintf("Perk Unlocked: %s!", e.Perk.Name))
+	})
+	game.On(events, m.announceVictory)
+	game.On(events, func(game.PlayerDefeated) {
+		m.notify("You were defeated! HP restored.")
+		m.SelectedMonsterID = ""
+		m.State = StateSlayerMonsterSelection
+	})
+}
+
+// notify shows a message until the next one replaces it
+func (m *Model) notify(message string) {
+	m.CurrentMessage = message
+	m.ShowMessage = true
+}
+
+// announceVictory shows the rewards for a defeated monster
+func (m *Model) announceVictory(e game.MonsterKilled) {
+	dropMessages := []string{}
+	for _, drop := range e.Monster.Drops {
+		if qty := e.Drops[drop.ItemID]; qty > 0 {
+			dropMessages = append(dropMessages, fmt.Sprintf("%dx %s", qty, drop.ItemName))
+		}
+	}
+
+	// Show completion message
+	dropStr := ""
+	if len(dropMessages) > 0 {
+		dropStr = fmt.Sprintf(" Drops: %s", dropMessages)
+	}
+	m.notify(fmt.Sprintf("Victory! +%s XP, +%s gold%s",
+		models.FormatNumber(e.CombatXP), models.FormatNumber(e.Gold), dropStr))
+
+	// Return to monster selection
+	m.State = StateSlayerMonsterSelection
+}
+
+// overflowMessage announces what the overflow policy did with items
+func overflowMessage(e game.ItemsOverflowed) string {
+	name := models.ItemName(e.ItemID)
+	switch e.Policy {
+	case models.OverflowSell:
+		return fmt.Sprintf("Inventory full! Sold %s for %s gold", name, models.FormatNumber(e.Gold))
+	case models.OverflowBank:
+		return fmt.Sprintf("Inventory full! %s sent to the bank", name)
+	}
+	return fmt.Sprintf("Inventory full! Dropped %s", name)
+}
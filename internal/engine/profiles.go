@@ -0,0 +1,254 @@
+package engine
+
+import (
+	"fmt"
+
+	"afk-tui/internal/data"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PickerMode is what the profile picker is currently doing
+type PickerMode int
+
+const (
+	PickerBrowse PickerMode = iota
+	PickerCreate
+	PickerRename
+	PickerDuplicate
+	PickerConfirmDelete
+)
+
+// ProfilePicker is the startup screen for choosing a save profile
+type ProfilePicker struct {
+	Profiles       *data.ProfileManager
+	Entries        []data.ProfileInfo
+	CursorPosition int
+	Mode           PickerMode
+	InputBuffer    string
+	Message        string
+
+	// Selected is the chosen profile, empty if the player quit
+	Selected string
+
+	// Views
+	Width  int
+	Height int
+}
+
+// NewProfilePicker creates a picker over the given profiles
+func NewProfilePicker(profiles *data.ProfileManager) *ProfilePicker {
+	p := &ProfilePicker{Profiles: profiles}
+	p.refresh()
+	return p
+}
+
+// Init implements tea.Model
+func (p *ProfilePicker) Init() tea.Cmd {
+	return nil
+}
+
+// Current returns the profile under the cursor
+func (p *ProfilePicker) Current() *data.ProfileInfo {
+	if p.CursorPosition < 0 || p.CursorPosition >= len(p.Entries) {
+		return nil
+	}
+	return &p.Entries[p.CursorPosition]
+}
+
+// refresh reloads the profile list, keeping the cursor in range
+func (p *ProfilePicker) refresh() {
+	entries, err := p.Profiles.List()
+	if err != nil {
+		p.Message = err.Error()
+	}
+	p.Entries = entries
+	if p.CursorPosition >= len(p.Entries) {
+		p.CursorPosition = len(p.Entries) - 1
+	}
+	if p.CursorPosition < 0 {
+		p.CursorPosition = 0
+	}
+}
+
+// selectByName moves the cursor to a profile
+func (p *ProfilePicker) selectByName(name string) {
+	for i, entry := range p.Entries {
+		if entry.Name == name {
+			p.CursorPosition = i
+			return
+		}
+	}
+}
+
+// Update handles messages
+func (p *ProfilePicker) Update(msg tea.Msg) (*ProfilePicker, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.Width = msg.Width
+		p.Height = msg.Height
+		return p, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			p.Selected = ""
+			return p, tea.Quit
+		}
+		switch p.Mode {
+		case PickerBrowse:
+			return p.handleBrowseInput(msg)
+		case PickerConfirmDelete:
+			return p.handleDeleteInput(msg)
+		default:
+			return p.handleNameInput(msg)
+		}
+	}
+
+	return p, nil
+}
+
+// handleBrowseInput handles the profile list
+func (p *ProfilePicker) handleBrowseInput(msg tea.KeyMsg) (*ProfilePicker, tea.Cmd) {
+	current := p.Current()
+
+	switch msg.String() {
+	case "up", "k":
+		if p.CursorPosition > 0 {
+			p.CursorPosition--
+		}
+
+	case "down", "j":
+		if p.CursorPosition < len(p.Entries)-1 {
+			p.CursorPosition++
+		}
+
+	case "enter":
+		if current != nil {
+			p.Selected = current.Name
+			return p, tea.Quit
+		}
+
+	case "n":
+		p.Mode = PickerCreate
+		p.InputBuffer = ""
+
+	case "r":
+		if current != nil {
+			p.Mode = PickerRename
+			p.InputBuffer = current.Name
+		}
+
+	case "c":
+		if current != nil {
+			p.Mode = PickerDuplicate
+			p.InputBuffer = current.Name + " copy"
+		}
+
+	case "x", "delete":
+		if current != nil {
+			p.Mode = PickerConfirmDelete
+		}
+
+	case "q", "esc":
+		p.Selected = ""
+		return p, tea.Quit
+	}
+
+	return p, nil
+}
+
+// handleDeleteInput asks for confirmation before deleting
+func (p *ProfilePicker) handleDeleteInput(msg tea.KeyMsg) (*ProfilePicker, tea.Cmd) {
+	current := p.Current()
+
+	switch msg.String() {
+	case "y", "Y":
+		if current != nil {
+			name := current.Name
+			if err := p.Profiles.Delete(name); err != nil {
+				p.Message = err.Error()
+			} else {
+				p.Message = fmt.Sprintf("Deleted profile %s", name)
+			}
+			p.refresh()
+		}
+		p.Mode = PickerBrowse
+
+	case "n", "N", "esc":
+		p.Mode = PickerBrowse
+	}
+
+	return p, nil
+}
+
+// handleNameInput handles typing a profile name for create, rename or duplicate
+func (p *ProfilePicker) handleNameInput(msg tea.KeyMsg) (*ProfilePicker, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.Mode = PickerBrowse
+		p.InputBuffer = ""
+		return p, nil
+
+	case tea.KeyEnter:
+		p.applyNameInput()
+		return p, nil
+
+	case tea.KeyBackspace:
+		if len(p.InputBuffer) > 0 {
+			p.InputBuffer = p.InputBuffer[:len(p.InputBuffer)-1]
+		}
+		return p, nil
+
+	case tea.KeySpace:
+		if len(p.InputBuffer) < 20 {
+			p.InputBuffer += " "
+		}
+		return p, nil
+
+	case tea.KeyRunes:
+		if len(p.InputBuffer)+len(msg.Runes) <= 20 {
+			p.InputBuffer += string(msg.Runes)
+		}
+		return p, nil
+	}
+
+	return p, nil
+}
+
+// applyNameInput runs the pending create, rename or duplicate
+func (p *ProfilePicker) applyNameInput() {
+	name := p.InputBuffer
+	var err error
+
+	switch p.Mode {
+	case PickerCreate:
+		_, err = p.Profiles.Create(name)
+		if err == nil {
+			p.Message = fmt.Sprintf("Created profile %s", name)
+		}
+	case PickerRename:
+		if current := p.Current(); current != nil {
+			err = p.Profiles.Rename(current.Name, name)
+			if err == nil {
+				p.Message = fmt.Sprintf("Renamed %s to %s", current.Name, name)
+			}
+		}
+	case PickerDuplicate:
+		if current := p.Current(); current != nil {
+			err = p.Profiles.Duplicate(current.Name, name)
+			if err == nil {
+				p.Message = fmt.Sprintf("Duplicated %s as %s", current.Name, name)
+			}
+		}
+	}
+
+	if err != nil {
+		// Stay in the input so the name can be fixed
+		p.Message = err.Error()
+		return
+	}
+
+	p.Mode = PickerBrowse
+	p.InputBuffer = ""
+	p.refresh()
+	p.selectByName(name)
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"afk-tui/internal/config"
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RecordingVersion is the format of session recordings
+const RecordingVersion = 1
+
+// A recording is JSON lines: a RecordingHeader, then a RecordedMsg for
+// every message that changes the game, in the order Model.Update got them,
+// then a RecordEnd with the final player. A recording cut short by a crash
+// still replays up to its last message. A RecordPlayerReplaced marks where
+// the player was swapped for a backup or an imported save, which a replay
+// can't reproduce.
+
+// RecordingHeader is everything needed to set the session up again
+type RecordingHeader struct {
+	Version  int              `json:"version"`
+	Seed     int64            `json:"seed"`
+	Start    time.Time        `json:"start"` // Offline progress is measured up to here
+	DevMode  bool             `json:"dev_mode,omitempty"`
+	Settings *config.Settings `json:"settings"`
+	Player   json.RawMessage  `json:"player"` // Before offline progress
+}
+
+// Kinds of recorded message
+const (
+	RecordKey            = "key"
+	RecordTick           = "tick"
+	RecordFastForward    = "fast_forward"
+	RecordPlayerReplaced = "player_replaced"
+	RecordEnd            = "end"
+)
+
+// RecordedMsg is one message delivered to Model.Update, or the end of the
+// recording
+type RecordedMsg struct {
+	At     time.Duration   `json:"at"` // Since the recording started
+	Kind   string          `json:"kind"`
+	Key    *RecordedKey    `json:"key,omitempty"`
+	Reason string          `json:"reason,omitempty"` // Why, for RecordPlayerReplaced
+	Player json.RawMessage `json:"player,omitempty"` // Final player, for RecordEnd
+}
+
+// RecordedKey is a key press
+type RecordedKey struct {
+	Type tea.KeyType `json:"type"`
+	Text string      `json:"text,omitempty"` // Runes typed or pasted
+	Alt  bool        `json:"alt,omitempty"`
+}
+
+// Msg returns the message to deliver to Model.Update, or nil for the end
+func (r RecordedMsg) Msg() tea.Msg {
+	switch r.Kind {
+	case RecordKey:
+		if r.Key == nil {
+			return nil
+		}
+		key := tea.Key{Type: r.Key.Type, Alt: r.Key.Alt}
+		if r.Key.Text != "" {
+			key.Runes = []rune(r.Key.Text)
+		}
+		return tea.KeyMsg(key)
+	case RecordTick:
+		return TickMsg{}
+	case RecordFastForward:
+		return FastForwardMsg{}
+	}
+	return nil
+}
+
+// Recorder writes a session recording as the model is updated
+type Recorder struct {
+	enc   *json.Encoder
+	start time.Time
+	err   error // First write error, reported by Finish
+}
+
+// StartRecording starts recording a session to w. It must be called before
+// Init, so offline progress is measured to a time the replay can use too.
+func StartRecording(w io.Writer, m *Model, settings *config.Settings) (*Recorder, error) {
+	if settings == nil {
+		settings = config.Default()
+	}
+	player, err := data.EncodePlayer(m.Player)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record player: %w", err)
+	}
+
+	r := &Recorder{enc: json.NewEncoder(w), start: time.Now()}
+	header := RecordingHeader{
+		Version:  RecordingVersion,
+		Seed:     m.Game.Seed,
+		Start:    r.start,
+		DevMode:  m.DevMode,
+		Settings: settings,
+		Player:   player,
+	}
+	if err := r.enc.Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+
+	// Wall clock only, as the replay reads it back from the header: with
+	// a monotonic reading, the time away would be measured differently
+	start := r.start.Round(0)
+	m.OfflineProcessor.Now = func() time.Time { return start }
+	m.Recorder = r
+	return r, nil
+}
+
+// Record writes a message delivered to Model.Update, if it is one that
+// changes the game
+func (r *Recorder) Record(msg tea.Msg) {
+	rec := RecordedMsg{At: time.Since(r.start)}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		rec.Kind = RecordKey
+		rec.Key = &RecordedKey{Type: msg.Type, Text: string(msg.Runes), Alt: msg.Alt}
+	case TickMsg:
+		rec.Kind = RecordTick
+	case FastForwardMsg:
+		rec.Kind = RecordFastForward
+	default:
+		return
+	}
+	r.write(rec)
+}
+
+// PlayerReplaced marks where the player was swapped for one from outside
+// the recording
+func (r *Recorder) PlayerReplaced(reason string) {
+	r.write(RecordedMsg{At: time.Since(r.start), Kind: RecordPlayerReplaced, Reason: reason})
+}
+
+// Finish writes the final player and returns the first error the recording
+// hit, if any
+func (r *Recorder) Finish(player *models.Player) error {
+	final, err := data.EncodePlayer(player)
+	if err != nil {
+		return fmt.Errorf("failed to record player: %w", err)
+	}
+	r.write(RecordedMsg{At: time.Since(r.start), Kind: RecordEnd, Player: final})
+	return r.err
+}
+
+// write encodes one line, keeping the first error
+func (r *Recorder) write(rec RecordedMsg) {
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(rec); err != nil {
+		r.err = fmt.Errorf("failed to write recording: %w", err)
+	}
+}
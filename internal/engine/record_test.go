@@ -0,0 +1,107 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"afk-tui/internal/config"
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordSession starts recording a new model for a player who has been
+// away chopping logs for two hours
+func recordSession(t *testing.T, out *bytes.Buffer) (*Model, *Recorder) {
+	t.Helper()
+	player := models.NewPlayer("Test")
+	player.Clock = nil
+	player.LastOnline = time.Now().Add(-2 * time.Hour)
+	player.CurrentActivity = models.NewActivity("chop_logs")
+
+	m := NewModel(player, data.NewSaveManager(t.TempDir()), config.Default())
+	m.SetSeed(7)
+	recorder, err := StartRecording(out, m, config.Default())
+	if err != nil {
+		t.Fatalf("StartRecording: %v", err)
+	}
+	m.Init()
+	return m, recorder
+}
+
+// press delivers key presses typed as text, e.g. "i"
+func press(m *Model, keys ...string) *Model {
+	for _, key := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	return m
+}
+
+// tick delivers n ticks
+func tick(m *Model, n int) *Model {
+	for i := 0; i < n; i++ {
+		m, _ = m.Update(TickMsg{})
+	}
+	return m
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var out bytes.Buffer
+	m, recorder := recordSession(t, &out)
+
+	// Leave the welcome back report, mine for a while, sell the logs and go
+	// back to chopping
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = tick(press(m, "2"), 300)
+	m = press(m, "i", "v", "1")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = press(m, "y", "d", "1")
+	m = tick(m, 300)
+	if err := recorder.Finish(m.Player); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	replay, err := LoadReplay(&out)
+	if err != nil {
+		t.Fatalf("LoadReplay: %v", err)
+	}
+	if replay.Final == nil {
+		t.Fatal("the recording has no final player")
+	}
+	replayed, err := replay.NewModel(data.NewSaveManager(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewModel: %v", err)
+	}
+	replayed = replay.Run(replayed)
+
+	same, err := SamePlayer(replay.Final, replayed.Player)
+	if err != nil {
+		t.Fatalf("SamePlayer: %v", err)
+	}
+	if !same {
+		t.Fatal("the replay ended with a different player than the recording")
+	}
+	if replayed.Player.Gold == 0 {
+		t.Error("nothing was sold, so the session didn't play as intended")
+	}
+}
+
+func TestReplayRefusesReplacedPlayer(t *testing.T) {
+	var out bytes.Buffer
+	m, recorder := recordSession(t, &out)
+
+	m = tick(m, 10)
+	if err := m.replacePlayer(models.NewPlayer("Other"), "Restored a backup", nil); err != nil {
+		t.Fatalf("replacePlayer: %v", err)
+	}
+	m = tick(m, 10)
+	if err := recorder.Finish(m.Player); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	if _, err := LoadReplay(&out); !errors.Is(err, ErrPlayerReplaced) {
+		t.Fatalf("LoadReplay err = %v, want %v", err, ErrPlayerReplaced)
+	}
+}
@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+)
+
+// ErrPlayerReplaced is returned for a recording of a session that restored
+// a backup or imported a save code. The player it switched to isn't in the
+// recording, so a replay would go on with a different one.
+var ErrPlayerReplaced = errors.New("the session replaced the player, so it can't be replayed")
+
+// Replay is a recorded session, ready to play back
+type Replay struct {
+	Header RecordingHeader
+	Msgs   []RecordedMsg  // Messages for Model.Update, in order
+	Final  *models.Player // Player at the end of the recording, or nil if it was cut short
+	Length time.Duration  // Time from the start to the last message
+}
+
+// LoadReplay reads a recording written by a Recorder
+func LoadReplay(r io.Reader) (*Replay, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024) // Lines hold whole players
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read recording: %w", err)
+		}
+		return nil, fmt.Errorf("recording is empty")
+	}
+	replay := &Replay{}
+	if err := json.Unmarshal(scanner.Bytes(), &replay.Header); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+	if replay.Header.Version != RecordingVersion {
+		return nil, fmt.Errorf("unsupported recording version %d (expected %d)", replay.Header.Version, RecordingVersion)
+	}
+
+	for line := 2; scanner.Scan(); line++ {
+		var rec RecordedMsg
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			// A crash can leave the last line half written
+			break
+		}
+		replay.Length = rec.At
+		if rec.Kind == RecordPlayerReplaced {
+			return nil, fmt.Errorf("line %d: %w (%s)", line, ErrPlayerReplaced, rec.Reason)
+		}
+		if rec.Kind == RecordEnd {
+			final, err := data.DecodePlayer(rec.Player)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid final player: %w", line, err)
+			}
+			replay.Final = final
+			break
+		}
+		if rec.Msg() == nil {
+			return nil, fmt.Errorf("line %d: unknown message %q", line, rec.Kind)
+		}
+		replay.Msgs = append(replay.Msgs, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return replay, nil
+}
+
+// NewModel sets up the recorded session again: the same player, settings,
+// seed and clock for offline progress. Saves go to saveManager, which should
+// not be the player's real save.
+func (r *Replay) NewModel(saveManager *data.SaveManager) (*Model, error) {
+	player, err := data.DecodePlayer(r.Header.Player)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded player: %w", err)
+	}
+
+	m := NewModel(player, saveManager, r.Header.Settings)
+	m.SetSeed(r.Header.Seed)
+	m.DevMode = r.Header.DevMode
+	start := r.Header.Start
+	m.OfflineProcessor.Now = func() time.Time { return start }
+	return m, nil
+}
+
+// Run plays the whole recording into a model from NewModel without a
+// terminal, and returns the model at the end
+func (r *Replay) Run(m *Model) *Model {
+	m.Init()
+	for _, rec := range r.Msgs {
+		m, _ = m.Update(rec.Msg())
+	}
+	return m
+}
+
+// SamePlayer reports whether two players are the same, apart from the wall
+// clock times a replay can't reproduce: when they were saved and when log
+// entries were written
+func SamePlayer(a, b *models.Player) (bool, error) {
+	ja, err := comparablePlayer(a)
+	if err != nil {
+		return false, err
+	}
+	jb, err := comparablePlayer(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ja, jb), nil
+}
+
+// comparablePlayer serializes a player without its wall clock times
+func comparablePlayer(player *models.Player) ([]byte, error) {
+	raw, err := json.Marshal(player)
+	if err != nil {
+		return nil, err
+	}
+	var stripped models.Player
+	if err := json.Unmarshal(raw, &stripped); err != nil {
+		return nil, err
+	}
+
+	stripped.LastOnline = time.Time{}
+	stripped.TotalPlaytime = 0
+	stripped.Clock = nil
+	if stripped.ActivityLog != nil {
+		for i := range stripped.ActivityLog.Entries {
+			stripped.ActivityLog.Entries[i].Timestamp = time.Time{}
+		}
+	}
+	return json.Marshal(&stripped)
+}
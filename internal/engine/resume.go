@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"afk-tui/internal/game"
+	"afk-tui/internal/models"
+)
+
+// resumeScreens names the screens a restart can return to. Anything else,
+// such as dialogs and the name editor, resumes on the dashboard.
+var resumeScreens = map[GameState]string{
+	StateDashboard:              "dashboard",
+	StateSkills:                 "skills",
+	StateInventory:              "inventory",
+	StateEquipment:              "equipment",
+	StateHelp:                   "help",
+	StateSkillCategories:        "skill_categories",
+	StateActivitySelection:      "activity_selection",
+	StateTraining:               "training",
+	StateCombat:                 models.ResumeScreenCombat,
+	StateSlayerTierSelection:    "slayer_tiers",
+	StateSlayerMonsterSelection: models.ResumeScreenSlayerMonsters,
+	StateCharacterSheet:         "character_sheet",
+}
+
+// captureResumeState records the current screen and fight on the player
+// so they are saved with it
+func (m *Model) captureResumeState() {
+	resume := &models.ResumeState{
+		Skill:      m.SelectedSkill,
+		Category:   m.SelectedCategory,
+		SlayerTier: m.SelectedSlayerTier,
+		MonsterID:  m.SelectedMonsterID,
+	}
+
+	// The welcome back report is shown again only after another absence
+	state := m.State
+	if state == StateWelcomeBack {
+		state = m.WelcomeBack.ReturnState
+	}
+
+	if screen, ok := resumeScreens[state]; ok {
+		resume.Screen = screen
+		resume.Cursor = m.CursorPosition
+	} else {
+		resume.Screen = resumeScreens[StateDashboard]
+	}
+
+	if encounter := m.Game.Fight; encounter != nil {
+		resume.Combat = encounter.Snapshot()
+	}
+
+	m.Player.Resume = resume
+}
+
+// applyResumeState returns to the screen and fight saved with the player
+func (m *Model) applyResumeState() {
+	resume := m.Player.Resume
+	if resume == nil {
+		return
+	}
+
+	if resume.Skill != "" {
+		m.SelectedSkill = resume.Skill
+	}
+	m.SelectedCategory = resume.Category
+	m.SelectedSlayerTier = resume.SlayerTier
+	m.SelectedMonsterID = resume.MonsterID
+
+	m.Game.Fight = game.RestoreEncounter(resume.Combat)
+
+	for state, screen := range resumeScreens {
+		if screen == resume.Screen {
+			m.State = state
+			m.CursorPosition = resume.Cursor
+			break
+		}
+	}
+
+	// A fight that can't be restored goes back to picking a monster
+	if m.State == StateCombat && m.Game.Fight == nil {
+		m.State = StateSlayerMonsterSelection
+		m.CursorPosition = 0
+	}
+	if m.State == StateSlayerMonsterSelection && m.SelectedSlayerTier == 0 {
+		m.State = StateSlayerTierSelection
+	}
+}
@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"afk-tui/internal/data"
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SaveCodeMode is what the save code screen is doing
+type SaveCodeMode int
+
+const (
+	SaveCodeExport SaveCodeMode = iota
+	SaveCodeImport
+	SaveCodeConfirm
+)
+
+// saveCodeFile is where [f] writes the export code, next to the save
+const saveCodeFile = "afk-tui-export.txt"
+
+// SaveCodeState tracks the export/import screen
+type SaveCodeState struct {
+	Mode     SaveCodeMode
+	Code     string // Export code of the current player
+	Input    string // Pasted import code
+	Imported *models.Player
+	Err      error
+	Notice   string
+}
+
+// openSaveCodeScreen shows the export code of the current game
+func (m *Model) openSaveCodeScreen() (*Model, tea.Cmd) {
+	m.Player.UpdatePlaytime()
+	m.Player.UpdateLastOnline()
+
+	code, err := data.ExportCode(m.Player)
+	m.SaveCode = SaveCodeState{Mode: SaveCodeExport, Code: code, Err: err}
+	m.State = StateSaveCode
+	m.CursorPosition = 0
+	return m, nil
+}
+
+// handleSaveCodeInput handles the export/import screen. Import mode takes
+// every key as text, so it is routed here before the global shortcuts.
+func (m *Model) handleSaveCodeInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	state := &m.SaveCode
+	if msg.Type == tea.KeyCtrlC {
+		m.Save()
+		return m, tea.Quit
+	}
+
+	switch state.Mode {
+	case SaveCodeExport:
+		switch msg.String() {
+		case "f":
+			path := filepath.Join(filepath.Dir(m.SaveManager.SavePath), saveCodeFile)
+			if err := os.WriteFile(path, []byte(state.Code+"\n"), 0644); err != nil {
+				state.Err = err
+			} else {
+				state.Notice = "Code written to " + path
+			}
+		case "i":
+			*state = SaveCodeState{Mode: SaveCodeImport}
+		case "esc", "q":
+			m.State = StateDashboard
+		}
+		return m, nil
+
+	case SaveCodeImport:
+		switch msg.Type {
+		case tea.KeyEnter:
+			player, err := data.ImportCode(state.Input)
+			if err != nil {
+				state.Err = err
+				return m, nil
+			}
+			state.Imported = player
+			state.Err = nil
+			state.Mode = SaveCodeConfirm
+		case tea.KeyBackspace:
+			if len(state.Input) > 0 {
+				state.Input = state.Input[:len(state.Input)-1]
+			}
+		case tea.KeyCtrlU:
+			state.Input = ""
+			state.Err = nil
+		case tea.KeyEsc:
+			m.State = StateDashboard
+		case tea.KeyRunes:
+			// Pastes arrive as one message with many runes
+			state.Input += string(msg.Runes)
+		}
+		return m, nil
+
+	case SaveCodeConfirm:
+		switch msg.String() {
+		case "y", "Y":
+			return m.importSaveCode()
+		case "n", "N", "esc":
+			state.Mode = SaveCodeImport
+			state.Imported = nil
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// importSaveCode replaces the current game with the validated import
+func (m *Model) importSaveCode() (*Model, tea.Cmd) {
+	imported := m.SaveCode.Imported
+	m.SaveCode = SaveCodeState{}
+
+	err := m.replacePlayer(imported,
+		fmt.Sprintf("Imported %s from a save code", imported.Name),
+		map[string]interface{}{"total_level": imported.GetTotalLevel()})
+	if err != nil {
+		m.CurrentMessage = fmt.Sprintf("Import failed: %v", err)
+	} else {
+		m.CurrentMessage = fmt.Sprintf("Imported %s", imported.Name)
+	}
+	m.ShowMessage = true
+	m.State = StateDashboard
+	m.CursorPosition = 0
+	return m, hideMessageCmd(3 * time.Second)
+}
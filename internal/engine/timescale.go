@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"afk-tui/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TimeScales are the speeds the game can run at, in ticks per tick rate
+var TimeScales = []int{1, 10, 100}
+
+// maxFastForward caps one fast-forward
+const maxFastForward = 7 * 24 * time.Hour
+
+// fastForwardChunk is how many ticks run between screen updates while
+// fast-forwarding, so the game stays responsive
+const fastForwardChunk = 2000
+
+// FastForwardState tracks a fast-forward under way
+type FastForwardState struct {
+	Duration  time.Duration // Game time being skipped
+	Total     int           // Ticks to run
+	Remaining int
+}
+
+// Active reports whether a fast-forward is under way
+func (f FastForwardState) Active() bool {
+	return f.Remaining > 0
+}
+
+// Progress returns how much of the fast-forward is done, from 0 to 1
+func (f FastForwardState) Progress() float64 {
+	if f.Total == 0 {
+		return 1
+	}
+	return float64(f.Total-f.Remaining) / float64(f.Total)
+}
+
+// FastForwardMsg runs the next chunk of a fast-forward
+type FastForwardMsg struct{}
+
+// fastForwardCmd asks for the next chunk of a fast-forward
+func fastForwardCmd() tea.Cmd {
+	return func() tea.Msg {
+		return FastForwardMsg{}
+	}
+}
+
+// SetTimeScale sets how many ticks run per tick rate. Each of them goes
+// through the same pipeline as a real-time tick.
+func (m *Model) SetTimeScale(scale int) error {
+	for _, s := range TimeScales {
+		if s == scale {
+			m.TimeScale = scale
+			m.Dirty = true
+			m.log().AddEntry(models.LogTypeSystem, fmt.Sprintf("Time scale set to %dx", scale),
+				map[string]interface{}{"time_scale": scale})
+			return nil
+		}
+	}
+	return fmt.Errorf("time scale must be one of %v", TimeScales)
+}
+
+// cycleTimeScale moves to the next time scale, back to 1x after the last
+func (m *Model) cycleTimeScale() (*Model, tea.Cmd) {
+	next := TimeScales[0]
+	for i, s := range TimeScales {
+		if s == m.TimeScale && i+1 < len(TimeScales) {
+			next = TimeScales[i+1]
+		}
+	}
+	m.SetTimeScale(next)
+	m.notify(fmt.Sprintf("Time scale: %dx", next))
+	return m, hideMessageCmd(2 * time.Second)
+}
+
+// runScaledTicks runs the ticks for one tick rate at the current time scale
+func (m *Model) runScaledTicks() {
+	scale := m.TimeScale
+	if scale < 1 {
+		scale = 1
+	}
+	m.processTicks(scale)
+}
+
+// startFastForward plays a stretch of game time as fast as possible, tick
+// by tick, in chunks between screen updates
+func (m *Model) startFastForward(d time.Duration) (*Model, tea.Cmd, error) {
+	if m.FastForward.Active() {
+		return m, nil, fmt.Errorf("already fast-forwarding")
+	}
+	if d <= 0 || d > maxFastForward {
+		return m, nil, fmt.Errorf("fast-forward must be more than 0 and at most %s", models.FormatDuration(maxFastForward))
+	}
+
+	ticks := int(d / m.TickRate)
+	m.FastForward = FastForwardState{Duration: d, Total: ticks, Remaining: ticks}
+	m.log().AddEntry(models.LogTypeSystem, fmt.Sprintf("Fast-forwarding %s (%s ticks)", models.FormatDuration(d), models.FormatNumber(int64(ticks))),
+		map[string]interface{}{"duration": d.String(), "ticks": ticks})
+	return m, fastForwardCmd(), nil
+}
+
+// handleFastForward runs the next chunk of a fast-forward
+func (m *Model) handleFastForward() (*Model, tea.Cmd) {
+	ff := &m.FastForward
+	if !ff.Active() {
+		return m, nil
+	}
+
+	chunk := fastForwardChunk
+	if chunk > ff.Remaining {
+		chunk = ff.Remaining
+	}
+	m.processTicks(chunk)
+	ff.Remaining -= chunk
+	m.Dirty = true
+	if ff.Active() {
+		return m, fastForwardCmd()
+	}
+
+	m.log().AddEntry(models.LogTypeSystem, fmt.Sprintf("Fast-forwarded %s", models.FormatDuration(ff.Duration)),
+		map[string]interface{}{"duration": ff.Duration.String(), "ticks": ff.Total})
+	m.notify(fmt.Sprintf("Fast-forwarded %s", models.FormatDuration(ff.Duration)))
+	return m, hideMessageCmd(3 * time.Second)
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"time"
+
+	"afk-tui/internal/data"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// welcomeBackMinAbsence is the shortest absence that opens the welcome back
+// report. Shorter ones are only logged.
+const welcomeBackMinAbsence = time.Minute
+
+// WelcomeBackState tracks the welcome back report
+type WelcomeBackState struct {
+	Report      *data.OfflineResult
+	ReturnState GameState // Screen to go back to when dismissed
+	Scroll      int       // First loot line shown
+}
+
+// openWelcomeBack shows the offline report over the resumed screen
+func (m *Model) openWelcomeBack() {
+	m.WelcomeBack.ReturnState = m.State
+	m.WelcomeBack.Scroll = 0
+	m.State = StateWelcomeBack
+}
+
+// handleWelcomeBackInput handles the welcome back report
+func (m *Model) handleWelcomeBackInput(msg tea.KeyMsg) (*Model, tea.Cmd) {
+	state := &m.WelcomeBack
+
+	switch msg.String() {
+	case "enter", "esc", " ", "space", "q":
+		m.State = state.ReturnState
+		state.Report = nil
+
+	case "i":
+		m.State = StateInventory
+		m.CursorPosition = 0
+		state.Report = nil
+
+	case "up", "k":
+		if state.Scroll > 0 {
+			state.Scroll--
+		}
+
+	case "down", "j":
+		if state.Scroll < len(state.Report.Loot())-1 {
+			state.Scroll++
+		}
+
+	case "ctrl+c":
+		m.Save()
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
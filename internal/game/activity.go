@@ -0,0 +1,114 @@
+package game
+
+import (
+	"afk-tui/internal/models"
+)
+
+// ActivityTick is what one tick of the current activity did
+type ActivityTick struct {
+	Activity  *models.Activity
+	Completed bool // An action finished this tick
+
+	XP       int64
+	OldLevel int
+	NewLevel int
+	Perks    []models.Perk
+
+	// Training activities raise an attribute as well as the Combat skill
+	Attribute         string
+	AttributeXP       int64
+	OldAttributeLevel int
+	NewAttributeLevel int
+
+	Multiplier int            // 2 for a double drop, 3 for a triple drop
+	Consumed   map[string]int // Inputs used by an action that started this tick
+
+	// Where the output went, through the player's overflow policy
+	models.Haul
+
+	// StoppedFor is the input the activity ran out of. The activity has
+	// been cleared from the player when it is set, or when Stop is.
+	StoppedFor string
+}
+
+// LeveledUp reports whether the tick gained a level
+func (t *ActivityTick) LeveledUp() bool {
+	return t.NewLevel > t.OldLevel
+}
+
+// AttributeLeveledUp reports whether the tick gained an attribute level
+func (t *ActivityTick) AttributeLeveledUp() bool {
+	return t.NewAttributeLevel > t.OldAttributeLevel
+}
+
+// TickActivity advances the player's current activity by one tick. It is
+// the single implementation of activity rules, shared by the live game and
+// offline progress. Modifiers are re-applied after a level or perk change
+// so the next action benefits straight away, and drops decides double and
+// triple drops.
+func TickActivity(player *models.Player, drops Drops) ActivityTick {
+	activity := player.CurrentActivity
+	tick := ActivityTick{Activity: activity}
+	if activity == nil {
+		return tick
+	}
+
+	// Activities restored from older saves may not have modifiers yet
+	if activity.SpeedMultiplier == 0 {
+		activity.ApplyModifiers(player)
+	}
+
+	if attr, name := trainedAttribute(player.Attributes, activity.ID); attr != nil {
+		return tickTraining(player, attr, name)
+	}
+
+	// Crafting and recycling pay for each action when it starts
+	if activity.Type == models.ActivityCrafting || activity.Type == models.ActivityRecycling {
+		if activity.Progress == 0 {
+			for itemID, qty := range activity.RequiredItems {
+				if !player.Inventory.HasItem(itemID, qty) {
+					player.CurrentActivity = nil
+					tick.StoppedFor = itemID
+					return tick
+				}
+			}
+
+			tick.Consumed = make(map[string]int, len(activity.RequiredItems))
+			for itemID, qty := range activity.RequiredItems {
+				player.Inventory.RemoveItem(itemID, qty)
+				tick.Consumed[itemID] = qty
+			}
+		}
+	}
+
+	if !activity.Tick() {
+		return tick
+	}
+
+	// Action completed
+	tick.Completed = true
+	tick.XP = activity.GetXP()
+
+	skill := player.GetSkill(activity.SkillType)
+	tick.OldLevel = skill.Level
+	tick.Perks = player.AddXP(activity.SkillType, tick.XP)
+	tick.NewLevel = skill.Level
+
+	tick.Multiplier = drops.Multiplier(activity)
+	output := activity.GetOutput()
+	for _, itemID := range models.SortedItemIDs(output) {
+		player.Store(&tick.Haul, itemID, output[itemID]*tick.Multiplier)
+	}
+
+	// Reset for next action
+	activity.Reset()
+	if tick.Stop {
+		player.CurrentActivity = nil
+		return tick
+	}
+	if tick.LeveledUp() || len(tick.Perks) > 0 {
+		activity.ApplyModifiers(player)
+	}
+
+	return tick
+}
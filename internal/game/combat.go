@@ -0,0 +1,205 @@
+package game
+
+import (
+	"fmt"
+
+	"afk-tui/internal/models"
+)
+
+// CombatEncounter tracks an active combat session
+type CombatEncounter struct {
+	Monster          *models.Monster
+	PlayerATB        float64 // 0-100
+	MonsterATB       float64 // 0-100
+	IsPlayerTurn     bool
+	CombatTicks      int
+	DamageDealt      int
+	DamageTaken      int
+	LastActionResult string
+}
+
+// NewCombatEncounter starts a fight against a fresh copy of a monster
+func NewCombatEncounter(monsterID string) *CombatEncounter {
+	monster := models.Monsters.SpawnMonster(monsterID)
+	if monster == nil {
+		return nil
+	}
+	return &CombatEncounter{
+		Monster:      monster,
+		IsPlayerTurn: true,
+	}
+}
+
+// RestoreEncounter rebuilds a saved fight, or returns nil if its monster no
+// longer exists
+func RestoreEncounter(snapshot *models.CombatSnapshot) *CombatEncounter {
+	if snapshot == nil {
+		return nil
+	}
+	monster := models.Monsters.SpawnMonster(snapshot.MonsterID)
+	if monster == nil {
+		return nil
+	}
+	monster.Hitpoints = min(max(snapshot.MonsterHP, 1), monster.MaxHP)
+	return &CombatEncounter{
+		Monster:          monster,
+		PlayerATB:        snapshot.PlayerATB,
+		MonsterATB:       snapshot.MonsterATB,
+		IsPlayerTurn:     snapshot.IsPlayerTurn,
+		CombatTicks:      snapshot.CombatTicks,
+		DamageDealt:      snapshot.DamageDealt,
+		DamageTaken:      snapshot.DamageTaken,
+		LastActionResult: snapshot.LastActionResult,
+	}
+}
+
+// Snapshot records the fight so it can be saved
+func (e *CombatEncounter) Snapshot() *models.CombatSnapshot {
+	return &models.CombatSnapshot{
+		MonsterID:        e.Monster.ID,
+		MonsterHP:        e.Monster.Hitpoints,
+		PlayerATB:        e.PlayerATB,
+		MonsterATB:       e.MonsterATB,
+		IsPlayerTurn:     e.IsPlayerTurn,
+		CombatTicks:      e.CombatTicks,
+		DamageDealt:      e.DamageDealt,
+		DamageTaken:      e.DamageTaken,
+		LastActionResult: e.LastActionResult,
+	}
+}
+
+// CombatTick is what one tick of a fight did
+type CombatTick struct {
+	Won      bool // The monster was defeated
+	Defeated bool // The player was defeated
+
+	// Rewards, set when Won
+	CombatXP     int64
+	SlayerXP     int64
+	Gold         int64
+	OldLevel     int // Combat skill level before the XP
+	NewLevel     int
+	SlayerLevels int // Slayer levels gained
+	Perks        []models.Perk
+
+	// Where the drops went, through the player's overflow policy
+	models.Haul
+}
+
+// LeveledUp reports whether the kill gained a Combat level
+func (t *CombatTick) LeveledUp() bool {
+	return t.NewLevel > t.OldLevel
+}
+
+// TickCombat advances a fight by one tick. It is the single implementation
+// of combat rules, shared by the live game and offline progress. A defeated
+// player has their HP restored; a finished fight is over either way and the
+// caller decides what comes next. Hits, damage and drops are rolled with
+// rng.
+func TickCombat(player *models.Player, encounter *CombatEncounter, rng models.RNG) CombatTick {
+	var tick CombatTick
+
+	// Fill ATB bars based on speed
+	playerSpeed := models.GetATBFill(1, player.Attributes.Dexterity.Level)
+	monsterSpeed := encounter.Monster.Speed * 5 // Convert speed to ATB fill rate
+
+	encounter.PlayerATB += playerSpeed
+	encounter.MonsterATB += monsterSpeed
+
+	// Process player turn
+	if encounter.PlayerATB >= 100 {
+		encounter.PlayerATB = 0
+		encounter.IsPlayerTurn = true
+
+		damage := models.CalculateDamage(
+			rng,
+			player.CombatStats.Attack,
+			player.Attributes.Strength.Level,
+			encounter.Monster.Defense,
+			models.CombatStyleMelee,
+			encounter.Monster.Weakness,
+			encounter.Monster.Resistance,
+		)
+
+		if damage > 0 {
+			encounter.Monster.Hitpoints -= damage
+			encounter.DamageDealt += damage
+			encounter.LastActionResult = fmt.Sprintf("You hit %s for %d damage!", encounter.Monster.Name, damage)
+
+			if encounter.Monster.Hitpoints <= 0 {
+				encounter.Monster.Hitpoints = 0
+				awardKill(player, encounter.Monster, rng, &tick)
+				return tick
+			}
+		} else {
+			encounter.LastActionResult = fmt.Sprintf("You missed %s!", encounter.Monster.Name)
+		}
+	}
+
+	// Process monster turn
+	if encounter.MonsterATB >= 100 {
+		encounter.MonsterATB = 0
+		encounter.IsPlayerTurn = false
+
+		damage := models.CalculateDamage(
+			rng,
+			encounter.Monster.Attack,
+			encounter.Monster.Strength,
+			player.Attributes.Defense.Level,
+			models.CombatStyleMelee,
+			"",
+			"",
+		)
+
+		if damage > 0 {
+			player.CombatStats.Hitpoints -= damage
+			encounter.DamageTaken += damage
+			encounter.LastActionResult = fmt.Sprintf("%s hits you for %d damage!", encounter.Monster.Name, damage)
+
+			if player.CombatStats.Hitpoints <= 0 {
+				// Defeat costs the fight, then HP is restored
+				player.CombatStats.Hitpoints = player.CombatStats.MaxHitpoints
+				tick.Defeated = true
+				return tick
+			}
+		} else {
+			encounter.LastActionResult = fmt.Sprintf("%s missed you!", encounter.Monster.Name)
+		}
+	}
+
+	encounter.CombatTicks++
+	return tick
+}
+
+// awardKill gives the player XP, gold and drops for a defeated monster
+func awardKill(player *models.Player, monster *models.Monster, rng models.RNG, tick *CombatTick) {
+	tick.Won = true
+	tick.CombatXP = monster.CombatXP
+	tick.SlayerXP = monster.SlayerXP
+	tick.Gold = monster.Gold
+
+	// Add Combat skill XP
+	skill := player.GetSkill(models.SkillCombat)
+	tick.OldLevel = skill.Level
+	tick.Perks = player.AddXP(models.SkillCombat, monster.CombatXP)
+	tick.NewLevel = skill.Level
+
+	// Add Slayer XP (directly to combat stats)
+	stats := player.CombatStats
+	stats.SlayerXP += monster.SlayerXP
+	for stats.SlayerXP >= models.CalculateXPToNext(stats.SlayerLevel) && stats.SlayerLevel < 120 {
+		stats.SlayerXP -= models.CalculateXPToNext(stats.SlayerLevel)
+		stats.SlayerLevel++
+		tick.SlayerLevels++
+	}
+
+	player.Gold += monster.Gold
+
+	// Roll for drops
+	for _, drop := range monster.Drops {
+		if !drop.AlwaysDrop && !models.RollDrop(rng, drop.DropRate) {
+			continue
+		}
+		player.Store(&tick.Haul, drop.ItemID, drop.Quantity)
+	}
+}
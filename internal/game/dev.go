@@ -0,0 +1,133 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"afk-tui/internal/models"
+)
+
+// Errors returned by the developer actions
+var (
+	ErrUnknownItem      = errors.New("unknown item")
+	ErrUnknownSkill     = errors.New("unknown skill")
+	ErrUnknownAttribute = errors.New("unknown attribute")
+	ErrInvalidAmount    = errors.New("invalid amount")
+)
+
+// maxLevel is the highest level a skill or attribute can reach
+const maxLevel = 120
+
+// The actions below change the player directly, for balance testing and
+// reproducing late-game states. Normal play never calls them.
+
+// Give puts items in the player's inventory, through the overflow policy
+// like any other items
+func (g *Game) Give(itemID string, quantity int) (models.Haul, error) {
+	if models.GetItemTemplate(itemID) == nil {
+		return models.Haul{}, fmt.Errorf("%w: %s", ErrUnknownItem, itemID)
+	}
+	if quantity <= 0 {
+		return models.Haul{}, fmt.Errorf("%w: %d", ErrInvalidAmount, quantity)
+	}
+
+	var haul models.Haul
+	g.Player.Store(&haul, itemID, quantity)
+	for _, event := range HaulEvents(&haul, g.Player.Overflow()) {
+		g.Events.Publish(event)
+	}
+	return haul, nil
+}
+
+// SetLevel sets a skill to a level with no XP towards the next one, and
+// unlocks the perks the new level qualifies for. Only a higher level is
+// published as a level up.
+func (g *Game) SetLevel(skillType models.SkillType, level int) error {
+	if _, ok := models.SkillNames[skillType]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSkill, skillType)
+	}
+	if level < 1 || level > maxLevel {
+		return fmt.Errorf("%w: level %d is not between 1 and %d", ErrInvalidAmount, level, maxLevel)
+	}
+
+	skill, ok := g.Player.Skills[skillType]
+	if !ok {
+		skill = models.NewSkill(skillType)
+		g.Player.Skills[skillType] = skill
+	}
+	from := skill.Level
+	skill.SetLevel(level)
+	g.publishLevelSet(LevelLowered{Skill: skillType, From: from, Level: level})
+	for _, perk := range g.Player.UnlockEarnedPerks() {
+		g.Events.Publish(PerkUnlocked{Perk: perk, Skill: perk.SkillType})
+	}
+
+	// Levels change how fast the current activity goes
+	if g.Player.CurrentActivity != nil {
+		g.Player.CurrentActivity.ApplyModifiers(g.Player)
+	}
+	return nil
+}
+
+// SetAttribute sets an attribute, such as "strength", to a level and
+// recalculates the combat stats derived from it
+func (g *Game) SetAttribute(name string, level int) error {
+	attr, display := attributeByName(g.Player, name)
+	if attr == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownAttribute, name)
+	}
+	if level < 1 || level > maxLevel {
+		return fmt.Errorf("%w: level %d is not between 1 and %d", ErrInvalidAmount, level, maxLevel)
+	}
+
+	from := attr.Level
+	attr.SetLevel(level)
+	if g.Player.CombatStats != nil {
+		g.Player.CombatStats.CalculateDerivedStats(g.Player.Attributes)
+	}
+	g.publishLevelSet(LevelLowered{Attribute: display, From: from, Level: level})
+	return nil
+}
+
+// publishLevelSet announces a level set by a developer action: a LevelUp
+// if it went up, LevelLowered if it went down, and nothing if it stayed
+func (g *Game) publishLevelSet(change LevelLowered) {
+	switch {
+	case change.Level > change.From:
+		g.Events.Publish(LevelUp{Skill: change.Skill, Attribute: change.Attribute, Level: change.Level})
+	case change.Level < change.From:
+		g.Events.Publish(change)
+	}
+}
+
+// SetGold sets the player's gold
+func (g *Game) SetGold(gold int64) error {
+	if gold < 0 {
+		return fmt.Errorf("%w: %d gold", ErrInvalidAmount, gold)
+	}
+	g.Player.Gold = gold
+	return nil
+}
+
+// attributeByName returns one of the player's attributes and its display
+// name, or nil if there is no such attribute
+func attributeByName(player *models.Player, name string) (*models.Attribute, string) {
+	if player.Attributes == nil {
+		player.Attributes = models.NewCharacterAttributes()
+	}
+	attrs := player.Attributes
+	switch strings.ToLower(name) {
+	case "strength":
+		return &attrs.Strength, "Strength"
+	case "dexterity":
+		return &attrs.Dexterity, "Dexterity"
+	case "defense":
+		return &attrs.Defense, "Defense"
+	case "constitution":
+		return &attrs.Constitution, "Constitution"
+	case "intelligence":
+		return &attrs.Intelligence, "Intelligence"
+	}
+	return nil, ""
+}
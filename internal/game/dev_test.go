@@ -0,0 +1,74 @@
+package game
+
+import (
+	"testing"
+
+	"afk-tui/internal/models"
+)
+
+func TestSetLevelEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     func(g *Game) error
+		want    Event
+		wantLog string
+	}{
+		{
+			name: "skill raised",
+			set:  func(g *Game) error { return g.SetLevel(models.SkillWoodcutting, 10) },
+			want: LevelUp{Skill: models.SkillWoodcutting, Level: 10},
+		},
+		{
+			name:    "skill lowered",
+			set:     func(g *Game) error { return g.SetLevel(models.SkillMining, 1) },
+			want:    LevelLowered{Skill: models.SkillMining, From: 5, Level: 1},
+			wantLog: "Dev: Mining lowered from level 5 to 1",
+		},
+		{
+			name: "skill unchanged",
+			set:  func(g *Game) error { return g.SetLevel(models.SkillMining, 5) },
+		},
+		{
+			name: "attribute raised",
+			set:  func(g *Game) error { return g.SetAttribute("strength", 3) },
+			want: LevelUp{Attribute: "Strength", Level: 3},
+		},
+		{
+			name:    "attribute lowered",
+			set:     func(g *Game) error { return g.SetAttribute("defense", 1) },
+			want:    LevelLowered{Attribute: "Defense", From: 4, Level: 1},
+			wantLog: "Dev: Defense lowered from level 4 to 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(models.NewPlayer("Test"), 1)
+			g.Player.Skills[models.SkillMining].SetLevel(5)
+			g.Player.Attributes.Defense.SetLevel(4)
+
+			var got []Event
+			On(g.Events, func(e LevelUp) { got = append(got, e) })
+			On(g.Events, func(e LevelLowered) { got = append(got, e) })
+			if err := tt.set(g); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+
+			if tt.want == nil {
+				if len(got) != 0 {
+					t.Errorf("published %+v, want nothing", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Fatalf("published %+v, want %+v", got, tt.want)
+			}
+			if tt.wantLog != "" {
+				entries := g.Player.ActivityLog.Entries
+				if last := entries[len(entries)-1]; last.Message != tt.wantLog || last.Type != models.LogTypeSystem {
+					t.Errorf("last log entry = %s %q, want %s %q", last.Type, last.Message, models.LogTypeSystem, tt.wantLog)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"afk-tui/internal/models"
+)
+
+// Drops decides how many times over a completed action pays out
+type Drops interface {
+	Multiplier(activity *models.Activity) int
+}
+
+// RollDrops rolls every action for a double or triple drop, as the live
+// game does
+type RollDrops struct {
+	Rand models.RNG
+}
+
+// Multiplier rolls one action
+func (d RollDrops) Multiplier(activity *models.Activity) int {
+	return activity.DropMultiplier(d.Rand)
+}
+
+// BulkDrops pays every action out once and counts them, so the double and
+// triple drops for a long stretch of offline progress can be drawn all at
+// once with Sample instead of rolled one by one
+type BulkDrops struct {
+	batches []*dropBatch
+}
+
+// dropBatch counts actions done with the same output and drop chances
+type dropBatch struct {
+	activity models.Activity
+	actions  int
+}
+
+// Multiplier counts the action and pays it out once
+func (d *BulkDrops) Multiplier(activity *models.Activity) int {
+	if n := len(d.batches); n > 0 {
+		last := d.batches[n-1]
+		if last.activity.ID == activity.ID &&
+			last.activity.DoubleChance == activity.DoubleChance &&
+			last.activity.TripleChance == activity.TripleChance {
+			last.actions++
+			return 1
+		}
+	}
+	d.batches = append(d.batches, &dropBatch{activity: *activity, actions: 1})
+	return 1
+}
+
+// BonusDrops is the extra output drawn by BulkDrops.Sample
+type BonusDrops struct {
+	Items   map[string]int
+	Triples int // Actions that paid out triple
+	Doubles int // Actions that paid out double
+}
+
+// Sample draws the double and triple drops for every counted action and
+// returns the output they add on top of what was already paid out
+func (d *BulkDrops) Sample(rng models.RNG) BonusDrops {
+	bonus := BonusDrops{Items: make(map[string]int)}
+	for _, batch := range d.batches {
+		triples, doubles := batch.activity.SampleDrops(rng, batch.actions)
+		bonus.Triples += triples
+		bonus.Doubles += doubles
+		if extra := 2*triples + doubles; extra > 0 {
+			for itemID, qty := range batch.activity.OutputItems {
+				bonus.Items[itemID] += qty * extra
+			}
+		}
+	}
+	d.batches = nil
+	return bonus
+}
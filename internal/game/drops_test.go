@@ -0,0 +1,41 @@
+package game
+
+import (
+	"testing"
+
+	"afk-tui/internal/models"
+)
+
+func TestRollDropsGivesDoublesAndTriples(t *testing.T) {
+	activity := models.NewActivity("chop_logs")
+	activity.DoubleChance = 0.25
+	activity.TripleChance = 0.10
+
+	drops := RollDrops{Rand: models.NewRand(1)}
+	const actions = 10000
+	counts := make(map[int]int)
+	for i := 0; i < actions; i++ {
+		counts[drops.Multiplier(activity)]++
+	}
+
+	if len(counts) != 3 {
+		t.Fatalf("multipliers %v, want only 1, 2 and 3", counts)
+	}
+	want := map[int]float64{1: 0.65, 2: 0.25, 3: 0.10}
+	for multiplier, share := range want {
+		got := float64(counts[multiplier]) / actions
+		if got < share-0.02 || got > share+0.02 {
+			t.Errorf("%dx on %.3f of actions, want about %.2f", multiplier, got, share)
+		}
+	}
+}
+
+func TestRollDropsWithoutPerks(t *testing.T) {
+	activity := models.NewActivity("chop_logs")
+	drops := RollDrops{Rand: models.NewRand(1)}
+	for i := 0; i < 1000; i++ {
+		if got := drops.Multiplier(activity); got != 1 {
+			t.Fatalf("multiplier %d without drop perks, want 1", got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"fmt"
+
+	"afk-tui/internal/models"
+)
+
+// logEvent writes an event to the player's activity log. It follows
+// g.Player, so the log stays right when the player is swapped for a backup.
+func (g *Game) logEvent(event Event) {
+	if g.Player.ActivityLog == nil {
+		g.Player.ActivityLog = models.NewActivityLog()
+	}
+	LogEvent(g.Player.ActivityLog, event)
+}
+
+// LogEvent writes an event to an activity log. XPGained and ItemGained are
+// only shown for offline progress: online, the ActionCompleted or
+// MonsterKilled before them already covers them.
+func LogEvent(log *models.ActivityLog, event Event) {
+	switch e := event.(type) {
+	case OfflineProgressSkipped:
+		log.AddEntry(models.LogTypeSystem, fmt.Sprintf("⚠️ Offline progress skipped: %s", e.Reason), map[string]interface{}{
+			"reason":      e.Reason,
+			"clock_flags": e.ClockFlags,
+		})
+
+	case OfflineProgress:
+		logOfflineProgress(log, e)
+
+	case XPGained:
+		if !e.Offline {
+			return
+		}
+		if e.Attribute != "" {
+			log.AddEntry(models.LogTypeXP, fmt.Sprintf("%s +%s XP while away", e.Attribute, models.FormatNumber(e.Amount)), map[string]interface{}{
+				"attribute": e.Attribute,
+				"amount":    e.Amount,
+				"level":     e.Level,
+			})
+			return
+		}
+		log.AddEntry(models.LogTypeXP, fmt.Sprintf("%s +%s XP while away", models.SkillNames[e.Skill], models.FormatNumber(e.Amount)), map[string]interface{}{
+			"skill":  e.Skill,
+			"amount": e.Amount,
+			"level":  e.Level,
+		})
+
+	case ItemGained:
+		if e.Offline {
+			log.AddItemLog(models.ItemName(e.ItemID), e.Quantity, e.ItemID)
+		}
+
+	case ActivityStarted:
+		log.AddActivityLog(e.Activity.Name, true)
+
+	case ActivityStopped:
+		log.AddActivityLog(e.Activity.Name, false)
+
+	case ActionCompleted:
+		// Combined XP and items entry
+		log.StartXPEntry(e.Activity.SkillType, e.XP, e.Level)
+		for _, itemID := range models.SortedItemIDs(e.Items) {
+			log.AddItemToPending(models.ItemName(itemID), e.Items[itemID])
+		}
+		log.FinalizePendingXP()
+
+	case LevelUp:
+		if e.Attribute != "" {
+			log.AddAttributeLevelUpLog(e.Attribute, e.Level)
+		} else {
+			log.AddLevelUpLog(e.Skill, e.Level)
+		}
+
+	case LevelLowered:
+		name, key, value := e.Attribute, "attribute", interface{}(e.Attribute)
+		if name == "" {
+			name, key, value = models.SkillNames[e.Skill], "skill", e.Skill
+		}
+		log.AddEntry(models.LogTypeSystem, fmt.Sprintf("Dev: %s lowered from level %d to %d", name, e.From, e.Level), map[string]interface{}{
+			key:     value,
+			"from":  e.From,
+			"level": e.Level,
+		})
+
+	case PerkUnlocked:
+		log.AddPerkLog(e.Perk.Name, e.Skill)
+
+	case ItemsOverflowed:
+		name := models.ItemName(e.ItemID)
+		details := map[string]interface{}{"item": e.ItemID, "quantity": e.Quantity, "overflow": e.Policy}
+		switch e.Policy {
+		case models.OverflowSell:
+			details["gold"] = e.Gold
+			log.AddEntry(models.LogTypeSell, fmt.Sprintf("Inventory full: sold %dx %s for %d gold", e.Quantity, name, e.Gold), details)
+		case models.OverflowBank:
+			log.AddEntry(models.LogTypeItem, fmt.Sprintf("Inventory full: %dx %s sent to the bank", e.Quantity, name), details)
+		default:
+			log.AddEntry(models.LogTypeItem, fmt.Sprintf("Inventory full: dropped %dx %s", e.Quantity, name), details)
+		}
+
+	case ItemSold:
+		log.AddSellLog(e.Name, e.Quantity, e.Gold)
+
+	case ItemEquipped:
+		log.AddEntry(models.LogTypeItem, fmt.Sprintf("Equipped %s", e.Item.Name), map[string]interface{}{
+			"item": e.Item.ID,
+			"slot": e.Item.Slot,
+		})
+
+	case MonsterKilled:
+		log.AddActivityLog(fmt.Sprintf("Defeated %s", e.Monster.Name), true)
+
+	case PlayerDefeated:
+		log.AddActivityLog("Defeated in combat", false)
+	}
+}
+
+// logOfflineProgress writes the summary of the time away: how long, what it
+// went to, and why it ended early if it did
+func logOfflineProgress(log *models.ActivityLog, e OfflineProgress) {
+	log.AddEntry(models.LogTypeSystem,
+		fmt.Sprintf("Away for %s: %d actions, %s XP gained", models.FormatDuration(e.Away), e.Actions, models.FormatNumber(e.XP)),
+		map[string]interface{}{
+			"offline_time": e.Away.String(),
+			"actions":      e.Actions,
+			"xp_gained":    e.XP,
+			"activity":     e.Activity,
+			"skill":        e.Skill,
+		})
+
+	if e.InCombat {
+		log.AddEntry(models.LogTypeActivity,
+			fmt.Sprintf("%s: %d kills, %d deaths, +%s gold, +%s Slayer XP", e.Activity,
+				e.Kills, e.Deaths, models.FormatNumber(e.Gold), models.FormatNumber(e.SlayerXP)),
+			map[string]interface{}{
+				"kills":         e.Kills,
+				"deaths":        e.Deaths,
+				"gold_gained":   e.Gold,
+				"slayer_xp":     e.SlayerXP,
+				"slayer_levels": e.SlayerLevels,
+			})
+	}
+
+	if e.Discarded > 0 {
+		log.AddEntry(models.LogTypeSystem,
+			fmt.Sprintf("Offline time capped at %s: %s of time away was not counted",
+				models.FormatDuration(e.Away), models.FormatDuration(e.Discarded)),
+			map[string]interface{}{
+				"discarded": e.Discarded.String(),
+				"cap":       e.Away.String(),
+			})
+	}
+
+	if e.StopReason != "" {
+		log.AddEntry(models.LogTypeActivity,
+			fmt.Sprintf("Stopped %s after %s: %s", e.Activity, models.FormatDuration(e.StoppedAfter), e.StopReason),
+			map[string]interface{}{
+				"activity":       e.Activity,
+				"stopped_after":  e.StoppedAfter.String(),
+				"items_consumed": e.Consumed,
+			})
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+func TestLogOfflineCap(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   OfflineProgress
+		wantCap string // Cap entry, or empty for none
+	}{
+		{
+			name:  "under the cap",
+			event: OfflineProgress{Away: 3 * time.Hour, Activity: "Chop Logs"},
+		},
+		{
+			name:    "past the cap",
+			event:   OfflineProgress{Away: 24 * time.Hour, Discarded: 48 * time.Hour, Activity: "Chop Logs"},
+			wantCap: "Offline time capped at 24h 0m: 48h 0m of time away was not counted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := models.NewActivityLog()
+			LogEvent(log, tt.event)
+
+			var capEntry string
+			for _, entry := range log.Entries {
+				if strings.Contains(entry.Message, "⚠️") {
+					t.Errorf("time away logged as suspicious: %q", entry.Message)
+				}
+				if strings.HasPrefix(entry.Message, "Offline time capped") {
+					capEntry = entry.Message
+				}
+			}
+			if capEntry != tt.wantCap {
+				t.Errorf("cap entry = %q, want %q", capEntry, tt.wantCap)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package game
+
+import (
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// Event is something that happened in the game. Subscribers switch on the
+// concrete type, or use On to receive a single type.
+type Event interface {
+	isEvent()
+}
+
+// ActivityStarted is published when the player starts an activity
+type ActivityStarted struct {
+	Activity *models.Activity
+}
+
+// ActivityStopped is published when the game stops the current activity
+type ActivityStopped struct {
+	Activity *models.Activity
+	Reason   string // e.g. "Ran out of Logs"
+}
+
+// ActionCompleted is published when an action of the current activity
+// finishes, before the XPGained and ItemGained events it caused
+type ActionCompleted struct {
+	Activity *models.Activity
+	XP       int64
+	Level    int            // Skill level after the XP
+	Items    map[string]int // Added to the inventory
+}
+
+// XPGained is published for every skill XP gain, and for every attribute XP
+// gain from training, online or while away
+type XPGained struct {
+	Skill     models.SkillType // Empty for an attribute
+	Attribute string           // Set for attribute XP from training
+	Amount    int64
+	Level     int  // Level after the gain
+	Offline   bool // Gained while away, with no ActionCompleted before it
+}
+
+// LevelUp is published when a skill, or an attribute from training, gains
+// a level
+type LevelUp struct {
+	Skill     models.SkillType // Empty for an attribute
+	Attribute string           // Set for an attribute level up
+	Level     int
+}
+
+// LevelLowered is published when a developer action sets a skill or an
+// attribute to a lower level than it had
+type LevelLowered struct {
+	Skill     models.SkillType // Empty for an attribute
+	Attribute string           // Set for an attribute
+	From      int
+	Level     int
+}
+
+// PerkUnlocked is published when the player earns a perk
+type PerkUnlocked struct {
+	Perk  models.Perk
+	Skill models.SkillType
+}
+
+// ItemGained is published when items go into the inventory
+type ItemGained struct {
+	ItemID   string
+	Quantity int
+	Offline  bool // Gained while away, with no ActionCompleted before it
+}
+
+// ItemsOverflowed is published when items don't fit in the inventory and
+// go through the player's overflow policy
+type ItemsOverflowed struct {
+	ItemID   string
+	Quantity int
+	Policy   models.OverflowPolicy // What happened to them
+	Gold     int64                 // Sale price, for OverflowSell
+}
+
+// ItemSold is published when the player sells items
+type ItemSold struct {
+	ItemID   string
+	Name     string
+	Quantity int
+	Gold     int64
+}
+
+// ItemEquipped is published when the player equips an item
+type ItemEquipped struct {
+	Item     *models.Item
+	Replaced *models.Item // Back in the inventory, or nil
+}
+
+// MonsterKilled is published when the player wins a fight
+type MonsterKilled struct {
+	Monster  *models.Monster
+	CombatXP int64
+	SlayerXP int64
+	Gold     int64
+	Drops    map[string]int // Added to the inventory
+}
+
+// PlayerDefeated is published when the player loses a fight
+type PlayerDefeated struct {
+	Monster *models.Monster
+}
+
+// OfflineProgress is published when the game catches up on the time the
+// player was away. The XP, levels, items and perks gained while away follow
+// it as their own events.
+type OfflineProgress struct {
+	Away         time.Duration // Time counted, up to the offline cap
+	Discarded    time.Duration // Time away beyond the offline cap
+	Activity     string        // Activity or monster the time went to
+	Skill        string
+	Actions      int
+	XP           int64
+	InCombat     bool // The time was spent fighting
+	Kills        int
+	Deaths       int
+	Gold         int64
+	SlayerXP     int64
+	SlayerLevels int
+	StopReason   string        // Why the activity stopped early, if it did
+	StoppedAfter time.Duration // Time away at which it stopped
+	Consumed     map[string]int
+}
+
+// OfflineProgressSkipped is published when the time away is not trusted,
+// e.g. because the system clock was changed
+type OfflineProgressSkipped struct {
+	Reason     string
+	ClockFlags int // Suspicious clock events so far
+}
+
+func (ActivityStarted) isEvent()        {}
+func (ActivityStopped) isEvent()        {}
+func (ActionCompleted) isEvent()        {}
+func (XPGained) isEvent()               {}
+func (LevelUp) isEvent()                {}
+func (LevelLowered) isEvent()           {}
+func (PerkUnlocked) isEvent()           {}
+func (ItemGained) isEvent()             {}
+func (ItemsOverflowed) isEvent()        {}
+func (ItemSold) isEvent()               {}
+func (ItemEquipped) isEvent()           {}
+func (MonsterKilled) isEvent()          {}
+func (PlayerDefeated) isEvent()         {}
+func (OfflineProgress) isEvent()        {}
+func (OfflineProgressSkipped) isEvent() {}
+
+// Bus delivers events to every subscriber, in the order they subscribed
+type Bus struct {
+	subscribers []func(Event)
+}
+
+// Subscribe adds a subscriber for every event
+func (b *Bus) Subscribe(subscriber func(Event)) {
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers an event to the subscribers
+func (b *Bus) Publish(event Event) {
+	for _, subscriber := range b.subscribers {
+		subscriber(event)
+	}
+}
+
+// On subscribes a handler to one type of event
+func On[E Event](b *Bus, handler func(E)) {
+	b.Subscribe(func(event Event) {
+		if e, ok := event.(E); ok {
+			handler(e)
+		}
+	})
+}
+
+// HaulEvents returns the events for where the items given to the player
+// went: ItemGained for the inventory and ItemsOverflowed for the rest
+func HaulEvents(haul *models.Haul, policy models.OverflowPolicy) []Event {
+	var events []Event
+	for _, itemID := range models.SortedItemIDs(haul.Items) {
+		events = append(events, ItemGained{ItemID: itemID, Quantity: haul.Items[itemID]})
+	}
+	for _, itemID := range models.SortedItemIDs(haul.Sold) {
+		qty := haul.Sold[itemID]
+		gold := int64(0)
+		if item := models.GetItemTemplate(itemID); item != nil {
+			gold = item.Value * int64(qty)
+		}
+		events = append(events, ItemsOverflowed{ItemID: itemID, Quantity: qty, Policy: models.OverflowSell, Gold: gold})
+	}
+	for _, itemID := range models.SortedItemIDs(haul.Banked) {
+		events = append(events, ItemsOverflowed{ItemID: itemID, Quantity: haul.Banked[itemID], Policy: models.OverflowBank})
+	}
+	for _, itemID := range models.SortedItemIDs(haul.Lost) {
+		// Lost items were either discarded or stopped the activity
+		events = append(events, ItemsOverflowed{ItemID: itemID, Quantity: haul.Lost[itemID], Policy: policy})
+	}
+	return events
+}
@@ -0,0 +1,252 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"afk-tui/internal/models"
+)
+
+// Errors returned by Game actions. They are wrapped with details, so check
+// them with errors.Is.
+var (
+	ErrUnknownActivity    = errors.New("unknown activity")
+	ErrUnknownMonster     = errors.New("unknown monster")
+	ErrRequirementsNotMet = errors.New("requirements not met")
+	ErrNotEnoughItems     = errors.New("not enough items")
+	ErrNotEquippable      = errors.New("item cannot be equipped")
+	ErrNoFight            = errors.New("not in a fight")
+)
+
+// Game is the rules of AFK-TUI for one player, with no user interface. A
+// frontend drives it by calling its actions and Step once per tick, and
+// shows the player and the ticks Step returns, or subscribes to its Events.
+// Simulations and tests can drive it the same way without a terminal.
+type Game struct {
+	Player *models.Player
+	Fight  *CombatEncounter // Fight under way, if any
+
+	// Rand is the only source of randomness in the game. The same seed
+	// and the same actions give the same results.
+	Seed int64
+	Rand *rand.Rand
+
+	// Events announces everything that happens in the game. The activity
+	// log is its first subscriber.
+	Events *Bus
+
+	// FightPaused holds the fight where it is while the activity carries
+	// on, e.g. while the frontend shows another screen
+	FightPaused bool
+}
+
+// NewGame creates a game for a player with its randomness seeded by seed
+func NewGame(player *models.Player, seed int64) *Game {
+	g := &Game{
+		Player: player,
+		Seed:   seed,
+		Rand:   models.NewRand(seed),
+		Events: &Bus{},
+	}
+	g.Events.Subscribe(g.logEvent)
+	return g
+}
+
+// Reseed restarts the game's randomness from seed. Rand keeps its
+// identity, so anything sharing it follows the new seed.
+func (g *Game) Reseed(seed int64) {
+	g.Seed = seed
+	g.Rand.Seed(seed)
+}
+
+// Tick is a game tick in which something happened
+type Tick struct {
+	Activity *ActivityTick   // Set when an action finished or the activity stopped
+	Combat   *CombatTick     // Set when a fight ended
+	Monster  *models.Monster // The monster the fight was against
+}
+
+// Step advances the game by n ticks: the fight if there is one and it is
+// not paused, the current activity otherwise. It returns the ticks in which
+// an action finished, the activity stopped or a fight ended.
+func (g *Game) Step(n int) []Tick {
+	var ticks []Tick
+	for i := 0; i < n; i++ {
+		if tick, ok := g.step(); ok {
+			ticks = append(ticks, tick)
+		}
+	}
+	return ticks
+}
+
+// step advances the game by one tick, reporting whether anything happened
+func (g *Game) step() (Tick, bool) {
+	if g.Fight != nil && !g.FightPaused {
+		monster := g.Fight.Monster
+		tick := TickCombat(g.Player, g.Fight, g.Rand)
+		if !tick.Won && !tick.Defeated {
+			return Tick{}, false
+		}
+		// Either way the fight is over
+		g.Fight = nil
+		g.publishCombat(monster, &tick)
+		return Tick{Combat: &tick, Monster: monster}, true
+	}
+
+	if g.Player.CurrentActivity == nil {
+		return Tick{}, false
+	}
+	tick := TickActivity(g.Player, RollDrops{Rand: g.Rand})
+	if !tick.Completed && tick.StoppedFor == "" {
+		return Tick{}, false
+	}
+	g.publishActivity(&tick)
+	return Tick{Activity: &tick}, true
+}
+
+// StartActivity makes an activity the player's current one
+func (g *Game) StartActivity(activityID string) (*models.Activity, error) {
+	activity := models.NewActivity(activityID)
+	if activity == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownActivity, activityID)
+	}
+	if err := activity.CanDo(g.Player); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRequirementsNotMet, err)
+	}
+
+	activity.ApplyModifiers(g.Player)
+	g.Player.CurrentActivity = activity
+	g.Events.Publish(ActivityStarted{Activity: activity})
+	return activity, nil
+}
+
+// StartCombat starts a fight against a monster, ending any fight under way
+func (g *Game) StartCombat(monsterID string) (*CombatEncounter, error) {
+	encounter := NewCombatEncounter(monsterID)
+	if encounter == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMonster, monsterID)
+	}
+	g.Fight = encounter
+	g.FightPaused = false
+	return encounter, nil
+}
+
+// Flee ends the fight under way with no rewards
+func (g *Game) Flee() error {
+	if g.Fight == nil {
+		return ErrNoFight
+	}
+	g.Fight = nil
+	return nil
+}
+
+// Sell sells items from the inventory at their value and returns the gold
+// they fetched
+func (g *Game) Sell(itemID string, quantity int) (int64, error) {
+	item := g.Player.Inventory.GetItem(itemID)
+	if item == nil {
+		return 0, fmt.Errorf("%w: no %s in the inventory", ErrNotEnoughItems, models.ItemName(itemID))
+	}
+	if quantity <= 0 || item.Quantity < quantity {
+		return 0, fmt.Errorf("%w: have %d %s, not %d", ErrNotEnoughItems, item.Quantity, item.Name, quantity)
+	}
+
+	name := item.Name
+	gold := item.Value * int64(quantity)
+	g.Player.Inventory.RemoveItem(itemID, quantity)
+	g.Player.Gold += gold
+	g.Events.Publish(ItemSold{ItemID: itemID, Name: name, Quantity: quantity, Gold: gold})
+	return gold, nil
+}
+
+// Equip moves one of an item from the inventory into its equipment slot and
+// returns the item it replaced, which goes back to the inventory
+func (g *Game) Equip(itemID string) (*models.Item, error) {
+	item := g.Player.Inventory.GetItem(itemID)
+	if item == nil {
+		return nil, fmt.Errorf("%w: no %s in the inventory", ErrNotEnoughItems, models.ItemName(itemID))
+	}
+	if !item.IsEquipable() {
+		return nil, fmt.Errorf("%w: %s", ErrNotEquippable, item.Name)
+	}
+	for skillType, level := range item.Requirements {
+		if g.Player.GetSkill(skillType).Level < level {
+			return nil, fmt.Errorf("%w: %s needs level %d %s", ErrRequirementsNotMet,
+				item.Name, level, models.SkillNames[skillType])
+		}
+	}
+
+	equipped := item.Clone()
+	equipped.Quantity = 1
+	old, err := g.Player.Equipment.Equip(g.Player.Inventory, equipped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tools change how fast the current activity goes
+	if g.Player.CurrentActivity != nil {
+		g.Player.CurrentActivity.ApplyModifiers(g.Player)
+	}
+	g.Events.Publish(ItemEquipped{Item: equipped, Replaced: old})
+	return old, nil
+}
+
+// publishActivity publishes what an activity tick did
+func (g *Game) publishActivity(tick *ActivityTick) {
+	activity := tick.Activity
+	if tick.StoppedFor != "" {
+		g.Events.Publish(ActivityStopped{
+			Activity: activity,
+			Reason:   fmt.Sprintf("Ran out of %s", models.ItemName(tick.StoppedFor)),
+		})
+		return
+	}
+
+	g.Events.Publish(ActionCompleted{Activity: activity, XP: tick.XP, Level: tick.NewLevel, Items: tick.Items})
+	g.Events.Publish(XPGained{Skill: activity.SkillType, Amount: tick.XP, Level: tick.NewLevel})
+	if tick.Attribute != "" && tick.AttributeXP > 0 {
+		g.Events.Publish(XPGained{Attribute: tick.Attribute, Amount: tick.AttributeXP, Level: tick.NewAttributeLevel})
+	}
+	for _, event := range HaulEvents(&tick.Haul, g.Player.Overflow()) {
+		g.Events.Publish(event)
+	}
+	if tick.Stop {
+		g.Events.Publish(ActivityStopped{Activity: activity, Reason: "Inventory full"})
+	}
+	if tick.AttributeLeveledUp() {
+		g.Events.Publish(LevelUp{Attribute: tick.Attribute, Level: tick.NewAttributeLevel})
+	}
+	if tick.LeveledUp() {
+		g.Events.Publish(LevelUp{Skill: activity.SkillType, Level: tick.NewLevel})
+	}
+	for _, perk := range tick.Perks {
+		g.Events.Publish(PerkUnlocked{Perk: perk, Skill: activity.SkillType})
+	}
+}
+
+// publishCombat publishes the end of a fight
+func (g *Game) publishCombat(monster *models.Monster, tick *CombatTick) {
+	if tick.Defeated {
+		g.Events.Publish(PlayerDefeated{Monster: monster})
+		return
+	}
+
+	g.Events.Publish(MonsterKilled{
+		Monster:  monster,
+		CombatXP: tick.CombatXP,
+		SlayerXP: tick.SlayerXP,
+		Gold:     tick.Gold,
+		Drops:    tick.Items,
+	})
+	g.Events.Publish(XPGained{Skill: models.SkillCombat, Amount: tick.CombatXP, Level: tick.NewLevel})
+	if tick.LeveledUp() {
+		g.Events.Publish(LevelUp{Skill: models.SkillCombat, Level: tick.NewLevel})
+	}
+	for _, perk := range tick.Perks {
+		g.Events.Publish(PerkUnlocked{Perk: perk, Skill: models.SkillCombat})
+	}
+	for _, event := range HaulEvents(&tick.Haul, g.Player.Overflow()) {
+		g.Events.Publish(event)
+	}
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"afk-tui/internal/models"
+)
+
+// playerJSON serializes a player without its wall clock times, which
+// differ between runs
+func playerJSON(t *testing.T, player *models.Player) []byte {
+	t.Helper()
+	clone := player.Clone()
+	clone.CreatedAt = time.Time{}
+	clone.LastOnline = time.Time{}
+	if clone.ActivityLog != nil {
+		for i := range clone.ActivityLog.Entries {
+			clone.ActivityLog.Entries[i].Timestamp = time.Time{}
+		}
+	}
+	data, err := json.Marshal(clone)
+	if err != nil {
+		t.Fatalf("failed to encode player: %v", err)
+	}
+	return data
+}
+
+// playSession fights chickens for a while, then chops logs with drop
+// perks, all driven by seed
+func playSession(t *testing.T, seed int64) *models.Player {
+	t.Helper()
+	g := NewGame(models.NewPlayer("Test"), seed)
+
+	for kills := 0; kills < 20; {
+		if g.Fight == nil {
+			if _, err := g.StartCombat("chicken"); err != nil {
+				t.Fatalf("StartCombat: %v", err)
+			}
+		}
+		for _, tick := range g.Step(50) {
+			if tick.Combat != nil && tick.Combat.Won {
+				kills++
+			}
+		}
+	}
+
+	if err := g.SetLevel(models.SkillWoodcutting, 80); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	if _, err := g.StartActivity("chop_logs"); err != nil {
+		t.Fatalf("StartActivity: %v", err)
+	}
+	g.Step(5000)
+	return g.Player
+}
+
+func TestSameSeedSameGame(t *testing.T) {
+	a := playerJSON(t, playSession(t, 42))
+	b := playerJSON(t, playSession(t, 42))
+	if !bytes.Equal(a, b) {
+		t.Fatal("two games with the same seed and actions ended with different players")
+	}
+
+	// The seed has to matter, or the test above proves nothing
+	c := playerJSON(t, playSession(t, 43))
+	if bytes.Equal(a, c) {
+		t.Fatal("games with different seeds ended with the same player")
+	}
+}
+
+func TestActionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(g *Game) error
+		want   error
+	}{
+		{
+			name: "unknown activity",
+			action: func(g *Game) error {
+				_, err := g.StartActivity("juggle")
+				return err
+			},
+			want: ErrUnknownActivity,
+		},
+		{
+			name: "activity above the player's level",
+			action: func(g *Game) error {
+				_, err := g.StartActivity("chop_oak")
+				return err
+			},
+			want: ErrRequirementsNotMet,
+		},
+		{
+			name: "crafting without the inputs",
+			action: func(g *Game) error {
+				_, err := g.StartActivity("smelt_bronze")
+				return err
+			},
+			want: ErrRequirementsNotMet,
+		},
+		{
+			name: "unknown monster",
+			action: func(g *Game) error {
+				_, err := g.StartCombat("dragon_king")
+				return err
+			},
+			want: ErrUnknownMonster,
+		},
+		{
+			name:   "flee without a fight",
+			action: func(g *Game) error { return g.Flee() },
+			want:   ErrNoFight,
+		},
+		{
+			name: "sell an item the player doesn't have",
+			action: func(g *Game) error {
+				_, err := g.Sell("logs", 1)
+				return err
+			},
+			want: ErrNotEnoughItems,
+		},
+		{
+			name: "sell more than the player has",
+			action: func(g *Game) error {
+				g.Give("logs", 3)
+				_, err := g.Sell("logs", 4)
+				return err
+			},
+			want: ErrNotEnoughItems,
+		},
+		{
+			name: "sell nothing",
+			action: func(g *Game) error {
+				g.Give("logs", 3)
+				_, err := g.Sell("logs", 0)
+				return err
+			},
+			want: ErrNotEnoughItems,
+		},
+		{
+			name: "equip an item the player doesn't have",
+			action: func(g *Game) error {
+				_, err := g.Equip("iron_axe")
+				return err
+			},
+			want: ErrNotEnoughItems,
+		},
+		{
+			name: "equip a resource",
+			action: func(g *Game) error {
+				g.Give("logs", 1)
+				_, err := g.Equip("logs")
+				return err
+			},
+			want: ErrNotEquippable,
+		},
+		{
+			name: "equip a tool above the player's level",
+			action: func(g *Game) error {
+				g.Give("steel_axe", 1)
+				_, err := g.Equip("steel_axe")
+				return err
+			},
+			want: ErrRequirementsNotMet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(models.NewPlayer("Test"), 1)
+			if err := tt.action(g); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrainingPublishesAttributeXP(t *testing.T) {
+	g := NewGame(models.NewPlayer("Test"), 1)
+	if _, err := g.StartActivity("strength_training"); err != nil {
+		t.Fatalf("StartActivity: %v", err)
+	}
+
+	actions := 0
+	var gains []XPGained
+	On(g.Events, func(ActionCompleted) { actions++ })
+	On(g.Events, func(e XPGained) {
+		if e.Attribute != "" {
+			gains = append(gains, e)
+		}
+	})
+	g.Step(500)
+
+	if actions == 0 {
+		t.Fatal("no training actions completed")
+	}
+	if len(gains) != actions {
+		t.Fatalf("%d attribute XP events for %d actions", len(gains), actions)
+	}
+	for _, e := range gains {
+		if e.Attribute != "Strength" || e.Amount <= 0 || e.Offline {
+			t.Fatalf("attribute XP event %+v, want online Strength XP", e)
+		}
+	}
+	if last := gains[len(gains)-1]; last.Level != g.Player.Attributes.Strength.Level {
+		t.Errorf("last event at level %d, Strength is level %d", last.Level, g.Player.Attributes.Strength.Level)
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"afk-tui/internal/models"
+)
+
+// trainedAttribute returns the attribute a training activity raises and its
+// display name, or nil if the activity is not training
+func trainedAttribute(attrs *models.CharacterAttributes, activityID string) (*models.Attribute, string) {
+	if attrs == nil {
+		return nil, ""
+	}
+	switch activityID {
+	case "strength_training":
+		return &attrs.Strength, "Strength"
+	case "dexterity_training":
+		return &attrs.Dexterity, "Dexterity"
+	case "defense_training":
+		return &attrs.Defense, "Defense"
+	}
+	return nil, ""
+}
+
+// tickTraining advances a training activity. A completed action gives its
+// XP to the trained attribute and half of it to the Combat skill, then
+// recalculates the combat stats derived from attributes.
+func tickTraining(player *models.Player, attr *models.Attribute, name string) ActivityTick {
+	activity := player.CurrentActivity
+	tick := ActivityTick{Activity: activity, Attribute: name}
+
+	if !activity.Tick() {
+		return tick
+	}
+
+	tick.Completed = true
+	tick.AttributeXP = activity.GetXP()
+	tick.OldAttributeLevel = attr.Level
+	attr.AddXP(tick.AttributeXP)
+	tick.NewAttributeLevel = attr.Level
+
+	// Half XP to the Combat skill
+	tick.XP = tick.AttributeXP / 2
+	skill := player.GetSkill(models.SkillCombat)
+	tick.OldLevel = skill.Level
+	tick.Perks = player.AddXP(models.SkillCombat, tick.XP)
+	tick.NewLevel = skill.Level
+
+	if player.CombatStats != nil {
+		player.CombatStats.CalculateDerivedStats(player.Attributes)
+	}
+
+	activity.Reset()
+	if tick.LeveledUp() || len(tick.Perks) > 0 {
+		activity.ApplyModifiers(player)
+	}
+
+	return tick
+}
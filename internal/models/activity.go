@@ -37,6 +37,7 @@ type Activity struct {
 	XPMultiplier    float64 `json:"-"`
 	SpeedMultiplier float64 `json:"-"`
 	DoubleChance    float64 `json:"-"`
+	TripleChance    float64 `json:"-"`
 
 	// Progress tracking
 	Progress       float64 `json:"progress"` // 0.0 to 1.0
@@ -64,7 +65,9 @@ func NewActivity(id string) *Activity {
 	return nil
 }
 
-// ApplyModifiers applies player bonuses to the activity
+// ApplyModifiers applies player bonuses to the activity. Call it when the
+// activity starts and again whenever a level, perk or equipment change
+// could alter them; an action already under way keeps its progress.
 func (a *Activity) ApplyModifiers(player *Player) {
 	skill := player.GetSkill(a.SkillType)
 
@@ -79,10 +82,18 @@ func (a *Activity) ApplyModifiers(player *Player) {
 	a.SpeedMultiplier = 1.0 + player.GetSkillMultiplier(a.SkillType)
 	a.SpeedMultiplier += float64(a.ToolPowerBonus) * 0.05 // 5% per tool power
 
-	// Double drop chance from perks
+	// Double and triple drop chances from perks
+	a.DoubleChance = 0
+	a.TripleChance = 0
 	for _, perk := range player.UnlockedPerks {
-		if perk.SkillType == a.SkillType && perk.Effect == PerkEffectDoubleDrop {
+		if perk.SkillType != a.SkillType {
+			continue
+		}
+		switch perk.Effect {
+		case PerkEffectDoubleDrop:
 			a.DoubleChance += perk.Value
+		case PerkEffectTripleDrop:
+			a.TripleChance += perk.Value
 		}
 	}
 
@@ -91,9 +102,18 @@ func (a *Activity) ApplyModifiers(player *Player) {
 		a.SpeedMultiplier += float64(skill.Level-10) * 0.01
 	}
 
-	// Calculate effective ticks
-	effectiveTicks := float64(a.BaseTicks) / a.SpeedMultiplier
-	a.TicksRemaining = int(math.Max(1, effectiveTicks))
+	// A new action takes the effective ticks; one under way can only get shorter
+	if effectiveTicks := a.EffectiveTicks(); a.Progress == 0 || a.TicksRemaining > effectiveTicks {
+		a.TicksRemaining = effectiveTicks
+	}
+}
+
+// EffectiveTicks returns how many ticks one action takes with the current modifiers
+func (a *Activity) EffectiveTicks() int {
+	if a.SpeedMultiplier <= 0 {
+		return max(1, a.BaseTicks)
+	}
+	return int(math.Max(1, float64(a.BaseTicks)/a.SpeedMultiplier))
 }
 
 // Tick processes one tick of the activity
@@ -121,31 +141,58 @@ func (a *Activity) GetXP() int64 {
 	return int64(float64(a.BaseXP) * a.XPMultiplier)
 }
 
-// GetOutput calculates output items with double chance
-func (a *Activity) GetOutput() map[string]int {
-	output := make(map[string]int)
+// DropChances returns the chances of one action yielding triple and double
+// output. Triple is rolled first and the two never add up to more than 1.
+func (a *Activity) DropChances() (triple, double float64) {
+	triple = math.Min(math.Max(a.TripleChance, 0), 1)
+	double = math.Min(math.Max(a.DoubleChance, 0), 1-triple)
+	return triple, double
+}
 
+// GetOutput returns the output of one action before any double or triple drop
+func (a *Activity) GetOutput() map[string]int {
+	output := make(map[string]int, len(a.OutputItems))
 	for itemID, quantity := range a.OutputItems {
-		dropMult := 1
-
-		// Check double/triple drop
-		if a.DoubleChance > 0 {
-			// Simple implementation - guaranteed extra at certain thresholds
-			if a.DoubleChance >= 1.0 {
-				dropMult = 2
-			}
-		}
+		output[itemID] = quantity
+	}
+	return output
+}
 
-		output[itemID] = quantity * dropMult
+// DropMultiplier rolls how many times over one action pays out: 3 for a
+// triple drop, 2 for a double drop, otherwise 1
+func (a *Activity) DropMultiplier(rng RNG) int {
+	triple, double := a.DropChances()
+	if triple == 0 && double == 0 {
+		return 1
 	}
+	roll := rng.Float64()
+	switch {
+	case roll < triple:
+		return 3
+	case roll < triple+double:
+		return 2
+	}
+	return 1
+}
 
-	return output
+// SampleDrops draws how many of a number of actions paid out triple and
+// double, from the same distribution as rolling each action with
+// DropMultiplier
+func (a *Activity) SampleDrops(rng RNG, actions int) (triples, doubles int) {
+	triple, double := a.DropChances()
+	triples = Binomial(rng, actions, triple)
+	if triple < 1 {
+		// Of the actions that were not triple, each is double with
+		// probability double / (1 - triple)
+		doubles = Binomial(rng, actions-triples, double/(1-triple))
+	}
+	return triples, doubles
 }
 
 // Reset resets progress for next action
 func (a *Activity) Reset() {
 	a.Progress = 0
-	a.TicksRemaining = int(float64(a.BaseTicks) / a.SpeedMultiplier)
+	a.TicksRemaining = a.EffectiveTicks()
 }
 
 // CanDo checks if player can perform this activity
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSampleDropsDistribution(t *testing.T) {
+	tests := []struct {
+		name           string
+		double, triple float64
+	}{
+		{"double only", 0.05, 0},
+		{"triple only", 0, 0.10},
+		{"double and triple", 0.25, 0.10},
+		{"chances over 1", 0.8, 0.6},
+	}
+
+	const actions, samples = 1000, 4000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := NewActivity("chop_logs")
+			activity.DoubleChance = tt.double
+			activity.TripleChance = tt.triple
+			triple, double := activity.DropChances()
+
+			rng := NewRand(1)
+			var triples, doubles []float64
+			for i := 0; i < samples; i++ {
+				tr, db := activity.SampleDrops(rng, actions)
+				if tr+db > actions {
+					t.Fatalf("%d triples and %d doubles from %d actions", tr, db, actions)
+				}
+				triples = append(triples, float64(tr))
+				doubles = append(doubles, float64(db))
+			}
+
+			// Each count is binomial over all the actions
+			checkBinomial(t, "triples", triples, actions, triple)
+			checkBinomial(t, "doubles", doubles, actions, double)
+		})
+	}
+}
+
+// checkBinomial compares the mean and variance of samples with those of a
+// binomial distribution, allowing for sampling error
+func checkBinomial(t *testing.T, what string, samples []float64, n int, p float64) {
+	t.Helper()
+	wantMean := float64(n) * p
+	wantVar := float64(n) * p * (1 - p)
+
+	var mean, variance float64
+	for _, x := range samples {
+		mean += x
+	}
+	mean /= float64(len(samples))
+	for _, x := range samples {
+		variance += (x - mean) * (x - mean)
+	}
+	variance /= float64(len(samples) - 1)
+
+	// Five standard errors of each estimate
+	meanTol := 5 * math.Sqrt(wantVar/float64(len(samples)))
+	varTol := 5 * wantVar * math.Sqrt(2/float64(len(samples)-1))
+	if math.Abs(mean-wantMean) > meanTol+1e-9 {
+		t.Errorf("%s: mean %.2f, want %.2f ± %.2f", what, mean, wantMean, meanTol)
+	}
+	if math.Abs(variance-wantVar) > varTol+1e-9 {
+		t.Errorf("%s: variance %.2f, want %.2f ± %.2f", what, variance, wantVar, varTol)
+	}
+}
+
+func TestSampleDropsMatchesDropMultiplier(t *testing.T) {
+	activity := NewActivity("chop_logs")
+	activity.DoubleChance = 0.25
+	activity.TripleChance = 0.10
+
+	const actions = 200000
+	rolled := 0
+	rng := NewRand(2)
+	for i := 0; i < actions; i++ {
+		rolled += activity.DropMultiplier(rng)
+	}
+	triples, doubles := activity.SampleDrops(NewRand(3), actions)
+	sampled := actions + 2*triples + doubles
+
+	// Both pay out 1.45 times over on average
+	want := 1.45 * actions
+	for name, got := range map[string]int{"rolled": rolled, "sampled": sampled} {
+		if math.Abs(float64(got)-want)/want > 0.01 {
+			t.Errorf("%s output %d, want about %.0f", name, got, want)
+		}
+	}
+}
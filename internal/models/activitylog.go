@@ -50,6 +50,18 @@ func NewActivityLog() *ActivityLog {
 	}
 }
 
+// Clone returns a copy of the log. Entries are never modified once added,
+// so their detail maps are shared.
+func (al *ActivityLog) Clone() *ActivityLog {
+	entries := make([]LogEntry, len(al.Entries))
+	copy(entries, al.Entries)
+	return &ActivityLog{
+		Entries:    entries,
+		MaxEntries: al.MaxEntries,
+		ScrollPos:  al.ScrollPos,
+	}
+}
+
 // AddEntry adds a new log entry
 func (al *ActivityLog) AddEntry(entryType LogType, message string, details map[string]interface{}) {
 	entry := LogEntry{
@@ -171,6 +183,14 @@ func (al *ActivityLog) AddLevelUpLog(skill SkillType, newLevel int) {
 	})
 }
 
+// AddAttributeLevelUpLog logs an attribute level up from training
+func (al *ActivityLog) AddAttributeLevelUpLog(attribute string, newLevel int) {
+	al.AddEntry(LogTypeLevelUp, fmt.Sprintf("🎉 %s Level %d!", attribute, newLevel), map[string]interface{}{
+		"attribute": attribute,
+		"new_level": newLevel,
+	})
+}
+
 // AddPerkLog logs perk unlock
 func (al *ActivityLog) AddPerkLog(perkName string, skill SkillType) {
 	al.AddEntry(LogTypePerk, fmt.Sprintf("✨ Perk: %s", perkName), map[string]interface{}{
@@ -0,0 +1,182 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const (
+	// ClockTolerance absorbs small corrections such as NTP adjustments and
+	// clocks that differ slightly between machines
+	ClockTolerance = 2 * time.Minute
+
+	// MaxPlausibleAway is the longest absence taken at face value. A longer
+	// one is far more likely a clock set years ahead than a returning player.
+	MaxPlausibleAway = 365 * 24 * time.Hour
+
+	// maxClockCheckpoints is how much clock history a save keeps
+	maxClockCheckpoints = 16
+)
+
+// ClockChain is the save's history of the system clock. Each save adds a
+// checkpoint linked to the one before by a hash, so the history can't be
+// shortened or have its times edited without the chain breaking. It is
+// meant to catch casual edits and clock changes, not a determined cheat.
+type ClockChain struct {
+	Checkpoints []ClockCheckpoint `json:"checkpoints"`
+	Flags       int               `json:"flags,omitempty"` // Suspicious clock events so far
+	LongAbsence *LongAbsence      `json:"long_absence,omitempty"`
+}
+
+// LongAbsence is the last absence longer than the offline cap. It looks
+// the same as a clock set ahead, so it is kept until the clock shows which
+// it was: going back far enough to bring it under the cap means the time
+// beyond the cap never passed.
+type LongAbsence struct {
+	From time.Time     `json:"from"`
+	To   time.Time     `json:"to"`
+	Cap  time.Duration `json:"cap"`
+}
+
+// ClockCheckpoint is the clock as seen by one save
+type ClockCheckpoint struct {
+	Wall   time.Time     `json:"wall"`
+	Played time.Duration `json:"played"` // TotalPlaytime at the time
+	Hash   string        `json:"hash"`
+}
+
+// checkpointHash links a checkpoint to the one before it
+func checkpointHash(prev string, wall time.Time, played time.Duration) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d", prev, wall.UnixNano(), played)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Clone returns a copy of the chain
+func (c *ClockChain) Clone() *ClockChain {
+	clone := *c
+	clone.Checkpoints = append([]ClockCheckpoint(nil), c.Checkpoints...)
+	if c.LongAbsence != nil {
+		absence := *c.LongAbsence
+		clone.LongAbsence = &absence
+	}
+	return &clone
+}
+
+// Latest returns the newest checkpoint, or nil for an empty chain
+func (c *ClockChain) Latest() *ClockCheckpoint {
+	if len(c.Checkpoints) == 0 {
+		return nil
+	}
+	return &c.Checkpoints[len(c.Checkpoints)-1]
+}
+
+// record appends a checkpoint, dropping the oldest beyond the limit. The
+// oldest kept checkpoint's hash becomes the trusted start of the chain.
+func (c *ClockChain) record(wall time.Time, played time.Duration) {
+	prev := ""
+	if latest := c.Latest(); latest != nil {
+		prev = latest.Hash
+	}
+	c.Checkpoints = append(c.Checkpoints, ClockCheckpoint{
+		Wall:   wall,
+		Played: played,
+		Hash:   checkpointHash(prev, wall, played),
+	})
+	if len(c.Checkpoints) > maxClockCheckpoints {
+		c.Checkpoints = append([]ClockCheckpoint(nil), c.Checkpoints[len(c.Checkpoints)-maxClockCheckpoints:]...)
+	}
+}
+
+// Verify checks that the chain is intact and that the clock never ran
+// slower than the game was played between checkpoints
+func (c *ClockChain) Verify() error {
+	for i := 1; i < len(c.Checkpoints); i++ {
+		prev, cur := c.Checkpoints[i-1], c.Checkpoints[i]
+		if cur.Hash != checkpointHash(prev.Hash, cur.Wall, cur.Played) {
+			return fmt.Errorf("clock history was edited")
+		}
+		if cur.Played < prev.Played {
+			return fmt.Errorf("playtime went backwards in the clock history")
+		}
+		if cur.Wall.Sub(prev.Wall) < cur.Played-prev.Played-ClockTolerance {
+			return fmt.Errorf("clock was turned back between saves")
+		}
+	}
+	return nil
+}
+
+// CheckClock decides whether the time since the player was last online can
+// be trusted. It returns an empty string if it can, or why it can't.
+func (p *Player) CheckClock(now time.Time) string {
+	if p.Clock != nil {
+		if err := p.Clock.Verify(); err != nil {
+			return err.Error()
+		}
+		if latest := p.Clock.Latest(); latest != nil && !latest.Wall.Equal(p.LastOnline) {
+			return "last online time does not match the clock history"
+		}
+	}
+
+	away := now.Sub(p.LastOnline)
+	if away < -ClockTolerance {
+		return fmt.Sprintf("clock moved backwards by %s", (-away).Round(time.Minute))
+	}
+	if away > MaxPlausibleAway {
+		return fmt.Sprintf("clock jumped %d days forward", int(away.Hours()/24))
+	}
+	return ""
+}
+
+// NoteLongAbsence remembers an absence longer than the offline cap, so a
+// later correction of the clock can show whether the clock had been set
+// ahead. It logs nothing: CheckClock has already passed the absence, and
+// the offline progress log says how much of it was capped.
+func (p *Player) NoteLongAbsence(now time.Time, away, limit time.Duration) {
+	if p.Clock == nil {
+		p.Clock = &ClockChain{}
+	}
+	now = now.Round(0)
+	p.Clock.LongAbsence = &LongAbsence{From: now.Add(-away), To: now, Cap: limit}
+}
+
+// checkLongAbsence flags the last long absence if the clock has gone back
+// far enough to bring it under the offline cap: the clock was set ahead,
+// then put right
+func (p *Player) checkLongAbsence(now time.Time) {
+	absence := p.Clock.LongAbsence
+	if absence == nil || !now.Round(0).Before(absence.From.Add(absence.Cap)) {
+		return
+	}
+	p.Clock.Flags++
+	p.Clock.LongAbsence = nil
+	p.logClockEvent(fmt.Sprintf("Clock went back past the %d hour absence before it: it was set ahead",
+		int(absence.To.Sub(absence.From).Hours())),
+		map[string]interface{}{
+			"absence_from": absence.From,
+			"absence_to":   absence.To,
+			"clock":        now.Round(0),
+		})
+}
+
+// ResetClock starts a new clock history from now after a suspicious clock
+// event, counting the event. The old history can't be trusted any more.
+func (p *Player) ResetClock(now time.Time) {
+	if p.Clock == nil {
+		p.Clock = &ClockChain{}
+	}
+	p.checkLongAbsence(now)
+	p.Clock.Flags++
+	p.Clock.Checkpoints = nil
+	p.LastOnline = now
+	p.Clock.record(now.Round(0), p.TotalPlaytime)
+}
+
+// logClockEvent writes a suspicious clock event to the activity log
+func (p *Player) logClockEvent(message string, details map[string]interface{}) {
+	if p.ActivityLog == nil {
+		p.ActivityLog = NewActivityLog()
+	}
+	p.ActivityLog.AddEntry(LogTypeSystem, "⚠️ "+message, details)
+}
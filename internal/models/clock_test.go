@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLongAbsenceFollowedByCorrection(t *testing.T) {
+	tests := []struct {
+		name      string
+		back      time.Duration // How far the clock goes back after the absence
+		wantFlags int
+	}{
+		{"real absence", 0, 0},
+		{"small correction after it", time.Hour, 1}, // Only the correction
+		{"clock put right", 40 * time.Hour, 2},      // And the absence it undid
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+			p := NewPlayer("Test")
+			p.Clock = nil
+			p.LastOnline = start
+			returned := start.Add(48 * time.Hour)
+
+			if problem := p.CheckClock(returned); problem != "" {
+				t.Fatalf("a 48 hour absence was rejected: %s", problem)
+			}
+			p.NoteLongAbsence(returned, 48*time.Hour, 24*time.Hour)
+			p.LastOnline = returned
+			if p.Clock.Flags != 0 {
+				t.Fatalf("a long absence alone counted %d flags", p.Clock.Flags)
+			}
+			if p.Clock.LongAbsence == nil {
+				t.Fatal("the long absence was not remembered")
+			}
+			if logContains(p, "⚠️") {
+				t.Fatal("a real absence was logged as suspicious")
+			}
+
+			if tt.back > 0 {
+				now := returned.Add(-tt.back)
+				if problem := p.CheckClock(now); problem == "" {
+					t.Fatal("the clock going back was not noticed")
+				}
+				p.ResetClock(now)
+			}
+			if p.Clock.Flags != tt.wantFlags {
+				t.Errorf("flags = %d, want %d", p.Clock.Flags, tt.wantFlags)
+			}
+			if undone := logContains(p, "it was set ahead"); undone != (tt.wantFlags == 2) {
+				t.Errorf("absence logged as set ahead: %v, want %v", undone, tt.wantFlags == 2)
+			}
+		})
+	}
+}
+
+// logContains reports whether the activity log mentions text
+func logContains(p *Player, text string) bool {
+	for _, entry := range p.ActivityLog.Entries {
+		if strings.Contains(entry.Message, text) {
+			return true
+		}
+	}
+	return false
+}
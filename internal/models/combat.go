@@ -86,6 +86,13 @@ func (a *Attribute) AddXP(amount int64) bool {
 	return leveledUp
 }
 
+// SetLevel puts the attribute at the start of a level
+func (a *Attribute) SetLevel(level int) {
+	a.Level = level
+	a.XP = 0
+	a.XPToNext = calculateAttributeXPToNext(level)
+}
+
 // CalculateXPToNext for attributes
 func calculateAttributeXPToNext(level int) int64 {
 	if level >= 120 {
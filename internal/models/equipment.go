@@ -22,6 +22,18 @@ func NewEquipment() *Equipment {
 	return &Equipment{}
 }
 
+// Clone returns a copy of the equipment with its own items
+func (e *Equipment) Clone() *Equipment {
+	clone := &Equipment{}
+	for _, slot := range EquipmentSlots {
+		if item := e.GetSlot(slot); item != nil {
+			itemCopy := *item
+			clone.SetSlot(slot, &itemCopy)
+		}
+	}
+	return clone
+}
+
 // GetSlot gets item in a specific slot
 func (e *Equipment) GetSlot(slot EquipmentSlot) *Item {
 	switch slot {
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatNumber shortens large numbers for display, e.g. 1.5K
+func FormatNumber(n int64) string {
+	if n >= 1000000000 {
+		return fmt.Sprintf("%.1fB", float64(n)/1000000000)
+	}
+	if n >= 1000000 {
+		return fmt.Sprintf("%.1fM", float64(n)/1000000)
+	}
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fK", float64(n)/1000)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// FormatDuration formats a duration as hours and minutes, e.g. 2h 5m
+func FormatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -107,6 +107,19 @@ func (inv *Inventory) SortByType() {
 	})
 }
 
+// Clone returns a copy of the inventory with its own item stacks
+func (inv *Inventory) Clone() *Inventory {
+	clone := &Inventory{
+		Items:    make([]*Item, len(inv.Items)),
+		MaxSlots: inv.MaxSlots,
+	}
+	for i, item := range inv.Items {
+		itemCopy := *item
+		clone.Items[i] = &itemCopy
+	}
+	return clone
+}
+
 // Count returns number of item stacks
 func (inv *Inventory) Count() int {
 	return len(inv.Items)
@@ -159,6 +172,32 @@ func (b *Bank) Deposit(inv *Inventory, itemID string, quantity int) bool {
 	return true
 }
 
+// Store puts items straight into the bank, stacking with any already there
+func (b *Bank) Store(item *Item) {
+	for _, bankItem := range b.Items {
+		if bankItem.ID == item.ID {
+			bankItem.Quantity += item.Quantity
+			return
+		}
+	}
+	b.Items = append(b.Items, item)
+}
+
+// Count returns number of item stacks
+func (b *Bank) Count() int {
+	return len(b.Items)
+}
+
+// Clone returns a copy of the bank with its own item stacks
+func (b *Bank) Clone() *Bank {
+	clone := &Bank{Items: make([]*Item, len(b.Items))}
+	for i, item := range b.Items {
+		itemCopy := *item
+		clone.Items[i] = &itemCopy
+	}
+	return clone
+}
+
 // Withdraw moves items from bank to inventory
 func (b *Bank) Withdraw(inv *Inventory, itemID string, quantity int) bool {
 	for i, item := range b.Items {
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"sort"
+	"sync"
+)
+
 // ItemType categorizes items
 type ItemType string
 
@@ -116,6 +121,12 @@ const (
 	SlotAmmo    EquipmentSlot = "ammo"
 )
 
+// EquipmentSlots lists every slot in display order
+var EquipmentSlots = []EquipmentSlot{
+	SlotHead, SlotBody, SlotLegs, SlotFeet, SlotHands, SlotWeapon,
+	SlotOffhand, SlotCape, SlotRing, SlotAmulet, SlotAmmo,
+}
+
 // ItemDatabase contains all item definitions
 var ItemDatabase = map[string]*Item{
 	// Resources - Wood
@@ -666,6 +677,77 @@ var ItemDatabase = map[string]*Item{
 	},
 }
 
+// knownItems maps every item ID the game can produce to its display name,
+// built on first use
+var (
+	knownItems     map[string]string
+	knownItemsOnce sync.Once
+)
+
+// loadKnownItems collects ItemDatabase entries, activity outputs, monster
+// drops and recycling yields. Not every drop has a template, so ItemDatabase
+// alone is not enough.
+func loadKnownItems() {
+	knownItemsOnce.Do(func() {
+		knownItems = make(map[string]string)
+		add := func(id, name string) {
+			if knownItems[id] == "" {
+				knownItems[id] = name
+			}
+		}
+		for itemID, item := range ItemDatabase {
+			knownItems[itemID] = item.Name
+		}
+		for _, item := range ItemDatabase {
+			for yield := range item.RecycleValue {
+				add(yield, yield)
+			}
+		}
+		for _, activity := range ActivityDatabase {
+			for itemID := range activity.OutputItems {
+				add(itemID, itemID)
+			}
+		}
+		for _, monster := range Monsters.monsters {
+			for _, drop := range monster.Drops {
+				if _, ok := ItemDatabase[drop.ItemID]; !ok && drop.ItemName != "" {
+					knownItems[drop.ItemID] = drop.ItemName
+				}
+				add(drop.ItemID, drop.ItemID)
+			}
+		}
+	})
+}
+
+// IsKnownItem reports whether the game can produce an item: an ItemDatabase
+// entry, an activity output, a monster drop or a recycling yield
+func IsKnownItem(id string) bool {
+	loadKnownItems()
+	_, ok := knownItems[id]
+	return ok
+}
+
+// ItemName returns an item's display name, including drops that have no
+// template, falling back to its ID
+func ItemName(id string) string {
+	loadKnownItems()
+	if name := knownItems[id]; name != "" {
+		return name
+	}
+	return id
+}
+
+// SortedItemIDs returns the item IDs in a set of quantities in order, so
+// items are handed out the same way every time
+func SortedItemIDs(items map[string]int) []string {
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // GetItemTemplate retrieves an item template
 func GetItemTemplate(id string) *Item {
 	if template, ok := ItemDatabase[id]; ok {
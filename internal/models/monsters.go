@@ -1,12 +1,12 @@
 package models
 
 import (
-	"math/rand"
+	"sort"
 )
 
 // RollDrop checks if a drop should occur based on drop rate (0.0 to 1.0)
-func RollDrop(dropRate float64) bool {
-	return rand.Float64() < dropRate
+func RollDrop(rng RNG, dropRate float64) bool {
+	return rng.Float64() < dropRate
 }
 
 // Monster represents an enemy to fight
@@ -52,7 +52,19 @@ func (db *MonsterDatabase) GetMonster(id string) *Monster {
 	return nil
 }
 
-// GetMonstersByLevelRange returns monsters within a level range
+// SpawnMonster returns a fresh copy of a monster with full HP. Fights must
+// use their own copy; the database entries are shared.
+func (db *MonsterDatabase) SpawnMonster(id string) *Monster {
+	template := db.GetMonster(id)
+	if template == nil {
+		return nil
+	}
+	monster := *template
+	monster.Hitpoints = monster.MaxHP
+	return &monster
+}
+
+// GetMonstersByLevelRange returns monsters within a level range, by level
 func (db *MonsterDatabase) GetMonstersByLevelRange(minLevel, maxLevel int) []*Monster {
 	var result []*Monster
 	for _, m := range db.monsters {
@@ -60,10 +72,22 @@ func (db *MonsterDatabase) GetMonstersByLevelRange(minLevel, maxLevel int) []*Mo
 			result = append(result, m)
 		}
 	}
+	sortMonsters(result)
 	return result
 }
 
-// GetBosses returns all boss monsters
+// sortMonsters orders monsters by level, then ID, so lists and random
+// picks from them don't depend on map order
+func sortMonsters(monsters []*Monster) {
+	sort.Slice(monsters, func(i, j int) bool {
+		if monsters[i].Level != monsters[j].Level {
+			return monsters[i].Level < monsters[j].Level
+		}
+		return monsters[i].ID < monsters[j].ID
+	})
+}
+
+// GetBosses returns all boss monsters, by level
 func (db *MonsterDatabase) GetBosses() []*Monster {
 	var result []*Monster
 	for _, m := range db.monsters {
@@ -71,16 +95,17 @@ func (db *MonsterDatabase) GetBosses() []*Monster {
 			result = append(result, m)
 		}
 	}
+	sortMonsters(result)
 	return result
 }
 
 // GetRandomMonster returns a random monster within level range
-func (db *MonsterDatabase) GetRandomMonster(minLevel, maxLevel int) *Monster {
+func (db *MonsterDatabase) GetRandomMonster(rng RNG, minLevel, maxLevel int) *Monster {
 	monsters := db.GetMonstersByLevelRange(minLevel, maxLevel)
 	if len(monsters) == 0 {
 		return nil
 	}
-	return monsters[rand.Intn(len(monsters))]
+	return monsters[rng.Intn(len(monsters))]
 }
 
 // Global monster database instance
@@ -318,7 +343,7 @@ var Monsters = &MonsterDatabase{
 }
 
 // CalculateDamage calculates damage based on attacker and defender stats
-func CalculateDamage(attackerAttack, attackerStrength int, defenderDefense int, style CombatStyle, weakness, resistance CombatStyle) int {
+func CalculateDamage(rng RNG, attackerAttack, attackerStrength int, defenderDefense int, style CombatStyle, weakness, resistance CombatStyle) int {
 	// Base damage calculation
 	accuracy := float64(attackerAttack) * 2
 	evasion := float64(defenderDefense) * 1.5
@@ -333,7 +358,7 @@ func CalculateDamage(attackerAttack, attackerStrength int, defenderDefense int,
 	}
 
 	// Check if hit lands
-	if rand.Float64() > hitChance {
+	if rng.Float64() > hitChance {
 		return 0 // Miss
 	}
 
@@ -344,7 +369,7 @@ func CalculateDamage(attackerAttack, attackerStrength int, defenderDefense int,
 		minHit = attackerStrength / 10
 	}
 
-	damage := minHit + rand.Intn(maxHit-minHit+1)
+	damage := minHit + rng.Intn(maxHit-minHit+1)
 
 	// Apply style modifiers
 	if style == weakness {
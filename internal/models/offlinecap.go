@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Offline cap upgrades extend how much time away counts for offline
+// progress, on top of the base cap from the settings file
+const (
+	OfflineCapUpgradeStep = 2 * time.Hour
+	MaxOfflineCapUpgrades = 12
+	offlineCapUpgradeBase = 10000 // Gold for the first upgrade; each one after costs double
+)
+
+// OfflineCapUpgradeCost returns the gold price of the next upgrade when
+// owned upgrades have been bought already
+func OfflineCapUpgradeCost(owned int) int64 {
+	return offlineCapUpgradeBase << owned
+}
+
+// OfflineCapBonus is the extra offline time from bought upgrades and perks
+func (p *Player) OfflineCapBonus() time.Duration {
+	return p.OfflineCapUpgradeBonus() + p.OfflineCapPerkBonus()
+}
+
+// OfflineCapUpgradeBonus is the extra offline time bought with gold
+func (p *Player) OfflineCapUpgradeBonus() time.Duration {
+	return time.Duration(p.OfflineCapUpgrades) * OfflineCapUpgradeStep
+}
+
+// OfflineCapPerkBonus is the extra offline time from perks. Perk values
+// are in hours.
+func (p *Player) OfflineCapPerkBonus() time.Duration {
+	var hours float64
+	for _, perk := range p.UnlockedPerks {
+		if perk.Effect == PerkEffectOfflineTime {
+			hours += perk.Value
+		}
+	}
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// BuyOfflineCapUpgrade spends gold on the next offline cap upgrade and
+// returns what it cost
+func (p *Player) BuyOfflineCapUpgrade() (int64, error) {
+	if p.OfflineCapUpgrades >= MaxOfflineCapUpgrades {
+		return 0, fmt.Errorf("offline cap is fully upgraded")
+	}
+	cost := OfflineCapUpgradeCost(p.OfflineCapUpgrades)
+	if p.Gold < cost {
+		return 0, fmt.Errorf("need %d gold (you have %d)", cost, p.Gold)
+	}
+	p.Gold -= cost
+	p.OfflineCapUpgrades++
+	return cost, nil
+}
@@ -0,0 +1,108 @@
+package models
+
+// OverflowPolicy decides what happens to gathered items that don't fit in
+// the inventory
+type OverflowPolicy string
+
+const (
+	OverflowDiscard OverflowPolicy = "discard" // Drop the items
+	OverflowStop    OverflowPolicy = "stop"    // Drop the items and stop the activity
+	OverflowSell    OverflowPolicy = "sell"    // Sell the items at their value
+	OverflowBank    OverflowPolicy = "bank"    // Move the items to the bank
+)
+
+// OverflowPolicies lists the policies in the order the inventory screen
+// cycles through them
+var OverflowPolicies = []OverflowPolicy{OverflowDiscard, OverflowStop, OverflowSell, OverflowBank}
+
+// Description explains the policy for the inventory screen
+func (o OverflowPolicy) Description() string {
+	switch o {
+	case OverflowStop:
+		return "Stop the activity"
+	case OverflowSell:
+		return "Sell the overflow"
+	case OverflowBank:
+		return "Send the overflow to the bank"
+	default:
+		return "Discard the overflow"
+	}
+}
+
+// Next returns the policy after this one
+func (o OverflowPolicy) Next() OverflowPolicy {
+	for i, policy := range OverflowPolicies {
+		if policy == o {
+			return OverflowPolicies[(i+1)%len(OverflowPolicies)]
+		}
+	}
+	return OverflowPolicies[0]
+}
+
+// Overflow returns the player's overflow policy. Saves without one discard
+// the overflow, as the game always did.
+func (p *Player) Overflow() OverflowPolicy {
+	for _, policy := range OverflowPolicies {
+		if p.OverflowPolicy == policy {
+			return policy
+		}
+	}
+	return OverflowDiscard
+}
+
+// Haul records where the items given to the player ended up
+type Haul struct {
+	Items    map[string]int // Added to the inventory
+	Sold     map[string]int // Overflow sold
+	Banked   map[string]int // Overflow moved to the bank
+	Lost     map[string]int // Overflow discarded
+	SaleGold int64          // Gold from the overflow sold
+	Stop     bool           // Overflow under the stop policy; the caller stops
+}
+
+// Full reports whether anything did not fit in the inventory
+func (h *Haul) Full() bool {
+	return len(h.Sold) > 0 || len(h.Banked) > 0 || len(h.Lost) > 0
+}
+
+// add counts a quantity in one of the haul's maps
+func (h *Haul) add(items *map[string]int, itemID string, qty int) {
+	if *items == nil {
+		*items = make(map[string]int)
+	}
+	(*items)[itemID] += qty
+}
+
+// Store gives the player items, putting what doesn't fit in the inventory
+// through their overflow policy, and records the outcome in the haul
+func (p *Player) Store(haul *Haul, itemID string, qty int) {
+	if qty <= 0 {
+		return
+	}
+	if p.Inventory.AddItem(NewItem(itemID, ItemName(itemID), qty)) {
+		haul.add(&haul.Items, itemID, qty)
+		return
+	}
+
+	switch p.Overflow() {
+	case OverflowSell:
+		gold := int64(0)
+		if item := GetItemTemplate(itemID); item != nil {
+			gold = item.Value * int64(qty)
+		}
+		p.Gold += gold
+		haul.SaleGold += gold
+		haul.add(&haul.Sold, itemID, qty)
+	case OverflowBank:
+		if p.Bank == nil {
+			p.Bank = NewBank(0)
+		}
+		p.Bank.Store(NewItem(itemID, ItemName(itemID), qty))
+		haul.add(&haul.Banked, itemID, qty)
+	case OverflowStop:
+		haul.Stop = true
+		haul.add(&haul.Lost, itemID, qty)
+	default:
+		haul.add(&haul.Lost, itemID, qty)
+	}
+}
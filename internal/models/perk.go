@@ -9,9 +9,11 @@ const (
 	PerkEffectXPBoost     PerkEffect = "xp_boost"
 	PerkEffectSpeedBoost  PerkEffect = "speed_boost"
 	PerkEffectDoubleDrop  PerkEffect = "double_drop"
+	PerkEffectTripleDrop  PerkEffect = "triple_drop"
 	PerkEffectAutoCollect PerkEffect = "auto_collect"
 	PerkEffectExtraSlot   PerkEffect = "extra_slot"
 	PerkEffectGoldBoost   PerkEffect = "gold_boost"
+	PerkEffectOfflineTime PerkEffect = "offline_time" // Value is hours
 )
 
 // Perk represents a permanent bonus
@@ -38,7 +40,8 @@ var AllPerks = []Perk{
 	{ID: "wc_double", Name: "Double Logs", Description: "5% chance for double logs", SkillType: SkillWoodcutting, LevelReq: 20, Effect: PerkEffectDoubleDrop, Value: 0.05},
 	{ID: "wc_speed_2", Name: "Expert Chopper", Description: "20% faster woodcutting", SkillType: SkillWoodcutting, LevelReq: 35, Effect: PerkEffectSpeedBoost, Value: 0.20},
 	{ID: "wc_xp_2", Name: "Forest Mastery", Description: "25% more Woodcutting XP", SkillType: SkillWoodcutting, LevelReq: 50, Effect: PerkEffectXPBoost, Value: 0.25},
-	{ID: "wc_triple", Name: "Triple Logs", Description: "10% chance for triple logs", SkillType: SkillWoodcutting, LevelReq: 80, Effect: PerkEffectDoubleDrop, Value: 0.10},
+	{ID: "wc_offline", Name: "Patient Woodsman", Description: "+2 hours offline progress", SkillType: SkillWoodcutting, LevelReq: 65, Effect: PerkEffectOfflineTime, Value: 2},
+	{ID: "wc_triple", Name: "Triple Logs", Description: "10% chance for triple logs", SkillType: SkillWoodcutting, LevelReq: 80, Effect: PerkEffectTripleDrop, Value: 0.10},
 
 	// Mining Perks
 	{ID: "mining_speed_1", Name: "Swift Pick", Description: "10% faster mining", SkillType: SkillMining, LevelReq: 5, Effect: PerkEffectSpeedBoost, Value: 0.10},
@@ -46,24 +49,28 @@ var AllPerks = []Perk{
 	{ID: "mining_double", Name: "Double Ore", Description: "5% chance for double ore", SkillType: SkillMining, LevelReq: 20, Effect: PerkEffectDoubleDrop, Value: 0.05},
 	{ID: "mining_speed_2", Name: "Expert Miner", Description: "20% faster mining", SkillType: SkillMining, LevelReq: 35, Effect: PerkEffectSpeedBoost, Value: 0.20},
 	{ID: "mining_xp_2", Name: "Earth Mastery", Description: "25% more Mining XP", SkillType: SkillMining, LevelReq: 50, Effect: PerkEffectXPBoost, Value: 0.25},
-	{ID: "mining_triple", Name: "Triple Ore", Description: "10% chance for triple ore", SkillType: SkillMining, LevelReq: 80, Effect: PerkEffectDoubleDrop, Value: 0.10},
+	{ID: "mining_offline", Name: "Night Shift", Description: "+2 hours offline progress", SkillType: SkillMining, LevelReq: 65, Effect: PerkEffectOfflineTime, Value: 2},
+	{ID: "mining_triple", Name: "Triple Ore", Description: "10% chance for triple ore", SkillType: SkillMining, LevelReq: 80, Effect: PerkEffectTripleDrop, Value: 0.10},
 
 	// Smithing Perks
 	{ID: "smith_xp_1", Name: "Apprentice Smith", Description: "15% more Smithing XP", SkillType: SkillSmithing, LevelReq: 10, Effect: PerkEffectXPBoost, Value: 0.15},
 	{ID: "smith_double", Name: "Efficient Smith", Description: "10% chance to save bars", SkillType: SkillSmithing, LevelReq: 25, Effect: PerkEffectDoubleDrop, Value: 0.10},
 	{ID: "smith_xp_2", Name: "Master Smith", Description: "25% more Smithing XP", SkillType: SkillSmithing, LevelReq: 50, Effect: PerkEffectXPBoost, Value: 0.25},
+	{ID: "smith_offline", Name: "Banked Forge", Description: "+2 hours offline progress", SkillType: SkillSmithing, LevelReq: 65, Effect: PerkEffectOfflineTime, Value: 2},
 	{ID: "smith_save", Name: "Bar Conservation", Description: "20% chance to save bars", SkillType: SkillSmithing, LevelReq: 80, Effect: PerkEffectDoubleDrop, Value: 0.20},
 
 	// Recycling Perks
 	{ID: "recycle_xp_1", Name: "Scavenger", Description: "15% more Recycling XP", SkillType: SkillRecycling, LevelReq: 10, Effect: PerkEffectXPBoost, Value: 0.15},
 	{ID: "recycle_bonus", Name: "Bonus Materials", Description: "25% more materials from recycling", SkillType: SkillRecycling, LevelReq: 25, Effect: PerkEffectDoubleDrop, Value: 0.25},
 	{ID: "recycle_xp_2", Name: "Master Recycler", Description: "30% more Recycling XP", SkillType: SkillRecycling, LevelReq: 50, Effect: PerkEffectXPBoost, Value: 0.30},
+	{ID: "recycle_offline", Name: "Sorting Machine", Description: "+2 hours offline progress", SkillType: SkillRecycling, LevelReq: 65, Effect: PerkEffectOfflineTime, Value: 2},
 	{ID: "recycle_super", Name: "Super Recycler", Description: "50% more materials from recycling", SkillType: SkillRecycling, LevelReq: 90, Effect: PerkEffectDoubleDrop, Value: 0.50},
 
 	// Combat Perks
 	{ID: "combat_xp_1", Name: "Warrior's Path", Description: "15% more Combat XP", SkillType: SkillCombat, LevelReq: 10, Effect: PerkEffectXPBoost, Value: 0.15},
 	{ID: "combat_gold", Name: "Loot Master", Description: "25% more gold from combat", SkillType: SkillCombat, LevelReq: 20, Effect: PerkEffectGoldBoost, Value: 0.25},
 	{ID: "combat_xp_2", Name: "Battle Veteran", Description: "25% more Combat XP", SkillType: SkillCombat, LevelReq: 50, Effect: PerkEffectXPBoost, Value: 0.25},
+	{ID: "combat_offline", Name: "Night Watch", Description: "+2 hours offline progress", SkillType: SkillCombat, LevelReq: 65, Effect: PerkEffectOfflineTime, Value: 2},
 
 	// Global Perks (any skill)
 	{ID: "global_slot", Name: "Extra Storage", Description: "+5 inventory slots", SkillType: "", LevelReq: 30, Effect: PerkEffectExtraSlot, Value: 5},
@@ -92,6 +99,16 @@ func GetAllPerksForSkill(skillType SkillType) []Perk {
 	return perks
 }
 
+// GetPerkByID finds a perk in AllPerks
+func GetPerkByID(id string) (Perk, bool) {
+	for _, perk := range AllPerks {
+		if perk.ID == id {
+			return perk, true
+		}
+	}
+	return Perk{}, false
+}
+
 // PerkManager tracks unlocked perks
 type PerkManager struct {
 	Unlocked map[string]bool
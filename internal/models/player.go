@@ -22,6 +22,12 @@ const (
 	SkillThieving    SkillType = "thieving"
 )
 
+// SkillTypes lists every skill in display order
+var SkillTypes = []SkillType{
+	SkillWoodcutting, SkillMining, SkillFishing, SkillSmithing, SkillRecycling,
+	SkillCombat, SkillCrafting, SkillCooking, SkillAgility, SkillThieving,
+}
+
 // SkillNames maps types to display names
 var SkillNames = map[SkillType]string{
 	SkillWoodcutting: "Woodcutting",
@@ -77,6 +83,18 @@ func (s *Skill) AddXP(amount int64) []Perk {
 	return unlockedPerks
 }
 
+// SetLevel puts the skill at the start of a level
+func (s *Skill) SetLevel(level int) {
+	s.Level = level
+	s.XP = 0
+	s.XPToNext = CalculateXPToNext(level)
+}
+
+// TotalXP returns all XP earned in the skill, including spent levels
+func (s *Skill) TotalXP() int64 {
+	return GetXPForLevel(s.Level) + s.XP
+}
+
 // CalculateXPToNext uses exponential curve
 func CalculateXPToNext(level int) int64 {
 	if level >= 120 {
@@ -101,19 +119,24 @@ func GetXPForLevel(level int) int64 {
 
 // Player represents the game state
 type Player struct {
-	Name            string               `json:"name"`
-	CreatedAt       time.Time            `json:"created_at"`
-	LastOnline      time.Time            `json:"last_online"`
-	TotalPlaytime   time.Duration        `json:"total_playtime"`
-	Skills          map[SkillType]*Skill `json:"skills"`
-	Inventory       *Inventory           `json:"inventory"`
-	Equipment       *Equipment           `json:"equipment"`
-	UnlockedPerks   []Perk               `json:"unlocked_perks"`
-	CurrentActivity *Activity            `json:"current_activity,omitempty"`
-	Gold            int64                `json:"gold"`
-	CombatStats     *CombatStats         `json:"combat_stats"`
-	Attributes      *CharacterAttributes `json:"attributes"` // Trainable stats
-	ActivityLog     *ActivityLog         `json:"activity_log"`
+	Name               string               `json:"name"`
+	CreatedAt          time.Time            `json:"created_at"`
+	LastOnline         time.Time            `json:"last_online"`
+	TotalPlaytime      time.Duration        `json:"total_playtime"`
+	Skills             map[SkillType]*Skill `json:"skills"`
+	Inventory          *Inventory           `json:"inventory"`
+	Equipment          *Equipment           `json:"equipment"`
+	UnlockedPerks      []Perk               `json:"unlocked_perks"`
+	CurrentActivity    *Activity            `json:"current_activity,omitempty"`
+	Gold               int64                `json:"gold"`
+	OfflineCapUpgrades int                  `json:"offline_cap_upgrades,omitempty"` // Bought with gold
+	Bank               *Bank                `json:"bank,omitempty"`                 // Overflow sent here by the bank policy
+	OverflowPolicy     OverflowPolicy       `json:"overflow_policy,omitempty"`      // What to do with items that don't fit
+	CombatStats        *CombatStats         `json:"combat_stats"`
+	Attributes         *CharacterAttributes `json:"attributes"` // Trainable stats
+	ActivityLog        *ActivityLog         `json:"activity_log"`
+	Resume             *ResumeState         `json:"resume,omitempty"` // Screen and fight to return to
+	Clock              *ClockChain          `json:"clock,omitempty"`  // Save times, to spot clock changes
 
 	// Session tracking (not saved)
 	SessionStart time.Time `json:"-"`
@@ -138,11 +161,7 @@ func NewPlayer(name string) *Player {
 	}
 
 	// Initialize all skills
-	for _, skillType := range []SkillType{
-		SkillWoodcutting, SkillMining, SkillFishing,
-		SkillSmithing, SkillRecycling, SkillCombat,
-		SkillCrafting, SkillCooking, SkillAgility, SkillThieving,
-	} {
+	for _, skillType := range SkillTypes {
 		p.Skills[skillType] = NewSkill(skillType)
 	}
 
@@ -169,14 +188,117 @@ func (p *Player) AddXP(skillType SkillType, amount int64) []Perk {
 	return unlockedPerks
 }
 
+// UnlockEarnedPerks unlocks perks the player's levels already qualify for,
+// such as perks added to the game after they were passed, and returns them
+func (p *Player) UnlockEarnedPerks() []Perk {
+	unlocked := make(map[string]bool, len(p.UnlockedPerks))
+	for _, perk := range p.UnlockedPerks {
+		unlocked[perk.ID] = true
+	}
+
+	var earned []Perk
+	for _, perk := range AllPerks {
+		if perk.SkillType == "" || unlocked[perk.ID] {
+			continue
+		}
+		if skill, ok := p.Skills[perk.SkillType]; ok && skill.Level >= perk.LevelReq {
+			earned = append(earned, perk)
+		}
+	}
+	p.UnlockedPerks = append(p.UnlockedPerks, earned...)
+	return earned
+}
+
 // GetOfflineTime returns time since last online
 func (p *Player) GetOfflineTime() time.Duration {
 	return time.Since(p.LastOnline)
 }
 
-// UpdateLastOnline updates the last online time
+// UpdateLastOnline updates the last online time and adds it to the clock
+// history. Readings taken this session carry Go's monotonic clock, so a
+// wall clock that fell behind it since the last update was turned back;
+// that is logged and the history starts over. A wall clock running ahead
+// is normal after the machine slept, which pauses the monotonic clock.
 func (p *Player) UpdateLastOnline() {
-	p.LastOnline = time.Now()
+	now := time.Now()
+	if p.Clock == nil {
+		p.Clock = &ClockChain{}
+	}
+
+	if prev := p.LastOnline; !prev.IsZero() {
+		drift := now.Round(0).Sub(prev.Round(0)) - now.Sub(prev)
+		if drift < -ClockTolerance {
+			p.checkLongAbsence(now)
+			p.Clock.Flags++
+			p.Clock.Checkpoints = nil
+			p.logClockEvent(fmt.Sprintf("System clock turned back %s while playing", (-drift).Round(time.Second)),
+				map[string]interface{}{"drift": drift.String()})
+		}
+	}
+
+	p.LastOnline = now
+	p.Clock.record(now.Round(0), p.TotalPlaytime)
+}
+
+// UpdatePlaytime adds the time since the last update to TotalPlaytime
+func (p *Player) UpdatePlaytime() {
+	now := time.Now()
+	if !p.SessionStart.IsZero() {
+		p.TotalPlaytime += now.Sub(p.SessionStart)
+	}
+	p.SessionStart = now
+}
+
+// Clone returns a deep copy of the player that can be read safely
+// while the original keeps changing, e.g. to save it in the background
+func (p *Player) Clone() *Player {
+	clone := *p
+
+	clone.Skills = make(map[SkillType]*Skill, len(p.Skills))
+	for skillType, skill := range p.Skills {
+		skillCopy := *skill
+		clone.Skills[skillType] = &skillCopy
+	}
+
+	clone.UnlockedPerks = append([]Perk(nil), p.UnlockedPerks...)
+
+	if p.Clock != nil {
+		clone.Clock = p.Clock.Clone()
+	}
+
+	if p.Inventory != nil {
+		clone.Inventory = p.Inventory.Clone()
+	}
+	if p.Equipment != nil {
+		clone.Equipment = p.Equipment.Clone()
+	}
+	if p.Bank != nil {
+		clone.Bank = p.Bank.Clone()
+	}
+	if p.CurrentActivity != nil {
+		activity := *p.CurrentActivity
+		clone.CurrentActivity = &activity
+	}
+	if p.CombatStats != nil {
+		stats := *p.CombatStats
+		if p.CombatStats.CurrentTask != nil {
+			task := *p.CombatStats.CurrentTask
+			stats.CurrentTask = &task
+		}
+		clone.CombatStats = &stats
+	}
+	if p.Attributes != nil {
+		attrs := *p.Attributes
+		clone.Attributes = &attrs
+	}
+	if p.ActivityLog != nil {
+		clone.ActivityLog = p.ActivityLog.Clone()
+	}
+	if p.Resume != nil {
+		clone.Resume = p.Resume.Clone()
+	}
+
+	return &clone
 }
 
 // GetTotalLevel returns sum of all skill levels + attribute levels
@@ -201,7 +323,8 @@ func (p *Player) GetTotalXP() int64 {
 	return total
 }
 
-// GetSkillMultiplier returns multiplier from perks
+// GetSkillMultiplier returns multiplier from perks. Double and triple drop
+// perks don't count: Activity.ApplyModifiers turns them into drop chances.
 func (p *Player) GetSkillMultiplier(skillType SkillType) float64 {
 	multiplier := 1.0
 	for _, perk := range p.UnlockedPerks {
@@ -211,9 +334,6 @@ func (p *Player) GetSkillMultiplier(skillType SkillType) float64 {
 				multiplier += perk.Value
 			case "speed_boost":
 				multiplier += perk.Value
-			case "double_drop":
-				// Handled separately
-				multiplier += perk.Value
 			}
 		}
 	}
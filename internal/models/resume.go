@@ -0,0 +1,42 @@
+package models
+
+// Resume screens that the game itself needs to recognise
+const (
+	ResumeScreenCombat         = "combat"
+	ResumeScreenSlayerMonsters = "slayer_monsters"
+)
+
+// ResumeState is where the player was when the game last saved, so a
+// restart puts them back on the same screen and in the same fight
+type ResumeState struct {
+	Screen     string          `json:"screen,omitempty"`
+	Skill      SkillType       `json:"skill,omitempty"`
+	Category   string          `json:"category,omitempty"`
+	Cursor     int             `json:"cursor,omitempty"`
+	SlayerTier int             `json:"slayer_tier,omitempty"`
+	MonsterID  string          `json:"monster_id,omitempty"`
+	Combat     *CombatSnapshot `json:"combat,omitempty"`
+}
+
+// CombatSnapshot is a fight in progress
+type CombatSnapshot struct {
+	MonsterID        string  `json:"monster_id"`
+	MonsterHP        int     `json:"monster_hp"`
+	PlayerATB        float64 `json:"player_atb"`
+	MonsterATB       float64 `json:"monster_atb"`
+	IsPlayerTurn     bool    `json:"is_player_turn"`
+	CombatTicks      int     `json:"combat_ticks"`
+	DamageDealt      int     `json:"damage_dealt"`
+	DamageTaken      int     `json:"damage_taken"`
+	LastActionResult string  `json:"last_action_result,omitempty"`
+}
+
+// Clone returns a copy of the resume state
+func (r *ResumeState) Clone() *ResumeState {
+	clone := *r
+	if r.Combat != nil {
+		combat := *r.Combat
+		clone.Combat = &combat
+	}
+	return &clone
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RNG is a source of randomness for game rules. *rand.Rand satisfies it,
+// so callers can pass a seeded one to get repeatable results.
+type RNG interface {
+	Float64() float64
+	Intn(n int) int
+}
+
+// NewRand returns a random number generator that always gives the same
+// numbers for the same seed
+func NewRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
+// NewSeed picks a seed for a session that wasn't given one
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Binomial draws the number of successes in n independent trials that each
+// succeed with probability p. It jumps from one success to the next with
+// geometrically distributed gaps, so it is exact and costs one random
+// number per success rather than one per trial.
+func Binomial(rng RNG, n int, p float64) int {
+	switch {
+	case n <= 0 || p <= 0:
+		return 0
+	case p >= 1:
+		return n
+	case p > 0.5:
+		return n - Binomial(rng, n, 1-p)
+	}
+
+	logQ := math.Log1p(-p)
+	successes, trial := 0, 0.0
+	for {
+		// 1-Float64 is in (0, 1], keeping the log finite
+		trial += math.Floor(math.Log(1-rng.Float64())/logQ) + 1
+		if trial > float64(n) {
+			return successes
+		}
+		successes++
+	}
+}
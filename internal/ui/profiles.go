@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"afk-tui/internal/engine"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProfilePickerView renders the startup profile picker
+func ProfilePickerView(p *engine.ProfilePicker) string {
+	if p.Width == 0 || p.Height == 0 {
+		return "Loading..."
+	}
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(" ⚔️ AFK-TUI - Choose a Profile "))
+	lines = append(lines, "")
+
+	if len(p.Entries) == 0 {
+		lines = append(lines, dimStyle.Render("  No profiles yet. Press [n] to create one."))
+	} else {
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("  %-20s %-8s %-10s %s", "Profile", "Total", "Playtime", "Last Online")))
+		for i, entry := range p.Entries {
+			var row string
+			if entry.Err != nil {
+				row = fmt.Sprintf("  %-20s %s", entry.Name, "(save unreadable)")
+			} else {
+				row = fmt.Sprintf("  %-20s %-8d %-10s %s",
+					entry.Name,
+					entry.TotalLevel,
+					formatPlaytime(entry.Playtime),
+					formatLastOnline(entry.LastOnline))
+			}
+
+			if i == p.CursorPosition {
+				lines = append(lines, selectedStyle.Render("▶"+row[1:]))
+			} else {
+				lines = append(lines, activityStyle.Render(row))
+			}
+		}
+	}
+	lines = append(lines, "")
+
+	switch p.Mode {
+	case engine.PickerCreate, engine.PickerRename, engine.PickerDuplicate:
+		prompt := map[engine.PickerMode]string{
+			engine.PickerCreate:    "New profile name:",
+			engine.PickerRename:    "Rename profile to:",
+			engine.PickerDuplicate: "Name for the copy:",
+		}[p.Mode]
+		lines = append(lines, labelStyle.Render("  "+prompt))
+		nameBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colorHighlight).
+			Padding(0, 1).
+			Width(24).
+			Render(p.InputBuffer + "▌")
+		lines = append(lines, "  "+nameBox)
+
+	case engine.PickerConfirmDelete:
+		if current := p.Current(); current != nil {
+			lines = append(lines, lipgloss.NewStyle().Foreground(colorDanger).Bold(true).
+				Render(fmt.Sprintf("  Delete profile %s and all its backups? [y/n]", current.Name)))
+		}
+	}
+
+	if p.Message != "" {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorHighlight).Render("  "+p.Message))
+	}
+
+	controls := "  [Enter] Play  [n] New  [r] Rename  [c] Duplicate  [x] Delete  [q] Quit  "
+	if p.Mode != engine.PickerBrowse && p.Mode != engine.PickerConfirmDelete {
+		controls = "  [Enter] Confirm  [Esc] Cancel  "
+	}
+	lines = append(lines, "")
+	lines = append(lines, lipgloss.NewStyle().
+		Background(lipgloss.Color("#333333")).
+		Foreground(colorInfo).
+		Render(controls))
+
+	return boxStyle.
+		Width(p.Width - 4).
+		Height(p.Height - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// formatPlaytime formats a playtime as hours and minutes
+func formatPlaytime(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+// formatLastOnline formats how long ago a profile was played
+func formatLastOnline(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	ago := time.Since(t)
+	switch {
+	case ago < time.Minute:
+		return "just now"
+	case ago < time.Hour:
+		return fmt.Sprintf("%dm ago", int(ago.Minutes()))
+	case ago < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(ago.Hours()))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
@@ -196,6 +196,14 @@ func View(m *engine.Model) string {
 		sections = append(sections, renderSlayerMonsterSelection(m, contentHeight))
 	case engine.StateCombat:
 		sections = append(sections, renderCombat(m, contentHeight))
+	case engine.StateRecovery:
+		sections = append(sections, renderRecovery(m, contentHeight))
+	case engine.StateBackupRestore:
+		sections = append(sections, renderBackupRestore(m, contentHeight))
+	case engine.StateSaveCode:
+		sections = append(sections, renderSaveCode(m, contentHeight))
+	case engine.StateWelcomeBack:
+		sections = append(sections, renderWelcomeBack(m, contentHeight))
 	default:
 		sections = append(sections, renderDashboard(m, contentHeight))
 	}
@@ -211,8 +219,12 @@ func View(m *engine.Model) string {
 	// Log panel (last 3 entries)
 	sections = append(sections, renderLogPanel(m))
 
-	// Footer
-	sections = append(sections, renderFooter(m))
+	// Footer, or the developer console when it is open
+	if m.Console.Open {
+		sections = append(sections, renderConsole(m))
+	} else {
+		sections = append(sections, renderFooter(m))
+	}
 
 	// Message overlay
 	result := lipgloss.JoinVertical(lipgloss.Left, sections...)
@@ -231,7 +243,7 @@ func renderHeader(m *engine.Model) string {
 	left := headerStyle.Render(" ⚔️ AFK-TUI ")
 
 	center := fmt.Sprintf("💰 %s | ⭐ Total: %d | 👤 %s",
-		formatNumber(player.Gold),
+		models.FormatNumber(player.Gold),
 		player.GetTotalLevel(),
 		player.Name)
 
@@ -251,32 +263,60 @@ func renderStatusBarWithAnimation(m *engine.Model) string {
 	if m.Player.CurrentActivity == nil {
 		return statusBarInactiveStyle.
 			Width(m.Width).
-			Render(" 📊 No Activity - Press [s] to select skill → letter to grind | [Space] for logs ")
+			Render(" 📊 No Activity - Press [s] to select skill → letter to grind | [Space] for logs" + renderTimeStatus(m) + renderSaveStatus(m) + " ")
 	}
 
 	activity := m.Player.CurrentActivity
 	progress := activity.Progress
 
-	// Get animation frame
-	frame := animationFrames[m.TickCount%len(animationFrames)]
+	// Get animation frame, held still when animations are turned off
+	animTick := m.TickCount
+	if !m.Animations {
+		animTick = 0
+	}
+	frame := animationFrames[animTick%len(animationFrames)]
 
 	// Create progress bar with gradient effect
-	progressBar := renderAnimatedProgressBar(progress, 35, m.TickCount)
+	progressBar := renderAnimatedProgressBar(progress, 35, animTick)
 
 	// Format status line
-	status := fmt.Sprintf(" %s %s %s | %s | %.0f%% | XP: +%d/tick | [Space] Logs",
+	status := fmt.Sprintf(" %s %s %s | %s | %.0f%% | XP: +%d/tick | [Space] Logs%s%s",
 		frame,
 		activity.Name,
 		getSkillIcon(activity.SkillType),
 		progressBar,
 		progress*100,
-		activity.GetXP())
+		activity.GetXP(),
+		renderTimeStatus(m),
+		renderSaveStatus(m))
 
 	return statusBarStyle.
 		Width(m.Width).
 		Render(status)
 }
 
+// renderTimeStatus shows a fast-forward under way or a time scale above 1x
+func renderTimeStatus(m *engine.Model) string {
+	if m.FastForward.Active() {
+		return fmt.Sprintf(" | ⏩ Fast-forward %.0f%%", m.FastForward.Progress()*100)
+	}
+	if m.TimeScale > 1 {
+		return fmt.Sprintf(" | ⏩ %dx", m.TimeScale)
+	}
+	return ""
+}
+
+// renderSaveStatus shows when the game last saved, or why saving failed
+func renderSaveStatus(m *engine.Model) string {
+	if m.LastSaveErr != nil {
+		return lipgloss.NewStyle().Foreground(colorDanger).Render(fmt.Sprintf(" | ⚠️ Save failed: %v", m.LastSaveErr))
+	}
+	if m.LastSaveTime.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(" | 💾 Saved %s", m.LastSaveTime.Format("15:04"))
+}
+
 // renderLogPanel renders the last 3 log entries
 func renderLogPanel(m *engine.Model) string {
 	// Safety check - initialize if nil
@@ -472,6 +512,8 @@ func renderFooter(m *engine.Model) string {
 		"[e]Equip",
 		"[Space]Logs",
 		"[Ctrl+S]Save",
+		"[Ctrl+R]Restore",
+		"[Ctrl+E]Export",
 		"[q]Quit",
 	}
 
@@ -488,6 +530,15 @@ func renderFooter(m *engine.Model) string {
 		Render(footer)
 }
 
+// renderConsole renders the developer console's command line
+func renderConsole(m *engine.Model) string {
+	return lipgloss.NewStyle().
+		Foreground(colorHighlight).
+		Background(lipgloss.Color("#222222")).
+		Width(m.Width).
+		Render(":" + m.Console.Input + "█")
+}
+
 // renderDashboard renders the main dashboard
 func renderDashboard(m *engine.Model, height int) string {
 	player := m.Player
@@ -602,7 +653,7 @@ func renderSkillsWithHotkeys(m *engine.Model, height int) string {
 
 		if isSelected {
 			lines = append(lines, fmt.Sprintf("     XP: %s / %s",
-				formatNumber(skill.XP), formatNumber(skill.XPToNext)))
+				models.FormatNumber(skill.XP), models.FormatNumber(skill.XPToNext)))
 
 			perks := models.GetAllPerksForSkill(s.skillType)
 			unlockedCount := 0
@@ -798,10 +849,10 @@ func renderInventory(m *engine.Model, height int) string {
 						isSelected := m.InventoryState.SelectedItem == itemNum
 						if isSelected {
 							line = selectedStyle.Render(fmt.Sprintf("  [%2d] %-22s x%d (%s gold)",
-								itemNum, item.Name, item.Quantity, formatNumber(item.Value)))
+								itemNum, item.Name, item.Quantity, models.FormatNumber(item.Value)))
 						} else {
 							line = fmt.Sprintf("  [%2d] %-22s x%d (%s gold)",
-								itemNum, item.Name, item.Quantity, formatNumber(item.Value))
+								itemNum, item.Name, item.Quantity, models.FormatNumber(item.Value))
 						}
 					} else {
 						// Normal view
@@ -829,7 +880,7 @@ func renderInventory(m *engine.Model, height int) string {
 			Render(fmt.Sprintf("CONFIRM SELL\n\nSell: %dx %s\nFor: %s gold\n\n[Y] Yes  [N] No",
 				m.InventoryState.QuantityToSell,
 				m.InventoryState.ItemName,
-				formatNumber(m.InventoryState.GoldValue)))
+				models.FormatNumber(m.InventoryState.GoldValue)))
 		lines = append(lines, confirmBox)
 	}
 
@@ -840,14 +891,19 @@ func renderInventory(m *engine.Model, height int) string {
 			lines = append(lines, labelStyle.Render(fmt.Sprintf("Selected: %s | Quantity: %d | Value: %s gold",
 				m.InventoryState.ItemName,
 				m.InventoryState.QuantityToSell,
-				formatNumber(m.InventoryState.GoldValue))))
+				models.FormatNumber(m.InventoryState.GoldValue))))
 			lines = append(lines, dimStyle.Render("Enter: Confirm | Backspace: Clear | max: All | Esc: Cancel"))
 		}
 	}
 
 	if !m.InventoryState.IsSellMode {
 		lines = append(lines, dimStyle.Render(fmt.Sprintf("Total Value: %s gold",
-			formatNumber(player.Inventory.GetTotalValue()))))
+			models.FormatNumber(player.Inventory.GetTotalValue()))))
+		overflow := fmt.Sprintf("When full: %s", player.Overflow().Description())
+		if player.Bank != nil && player.Bank.Count() > 0 {
+			overflow += fmt.Sprintf("  |  Bank: %d stacks", player.Bank.Count())
+		}
+		lines = append(lines, dimStyle.Render(overflow))
 	}
 
 	// Footer
@@ -861,7 +917,7 @@ func renderInventory(m *engine.Model, height int) string {
 		lines = append(lines, lipgloss.NewStyle().
 			Background(lipgloss.Color("#333333")).
 			Foreground(colorInfo).
-			Render("  [#] Quick sell  [v] Sell/Vend  [Esc/q] Back  "))
+			Render("  [#] Quick sell  [v] Sell/Vend  [f] When full  [Esc/q] Back  "))
 	}
 
 	return boxStyle.
@@ -941,6 +997,8 @@ func renderHelp(m *engine.Model, height int) string {
 		{"e", "Equipment"},
 		{"?/h", "This help"},
 		{"Ctrl+S", "Save game"},
+		{"Ctrl+R", "Restore a backup"},
+		{"Ctrl+E", "Export/import a save code"},
 		{"q", "Save & quit"},
 		{"", ""},
 		{"Combat", ""},
@@ -1042,11 +1100,11 @@ func renderCharacterSheet(m *engine.Model, height int) string {
 	// Attributes
 	lines = append(lines, categoryStyle.Render("💪 Attributes"))
 	attrs := player.Attributes
-	lines = append(lines, fmt.Sprintf("  Strength:     Lv.%d (XP: %s)", attrs.Strength.Level, formatNumber(attrs.Strength.XP)))
-	lines = append(lines, fmt.Sprintf("  Dexterity:    Lv.%d (XP: %s)", attrs.Dexterity.Level, formatNumber(attrs.Dexterity.XP)))
-	lines = append(lines, fmt.Sprintf("  Defense:      Lv.%d (XP: %s)", attrs.Defense.Level, formatNumber(attrs.Defense.XP)))
-	lines = append(lines, fmt.Sprintf("  Constitution: Lv.%d (XP: %s)", attrs.Constitution.Level, formatNumber(attrs.Constitution.XP)))
-	lines = append(lines, fmt.Sprintf("  Intelligence: Lv.%d (XP: %s)", attrs.Intelligence.Level, formatNumber(attrs.Intelligence.XP)))
+	lines = append(lines, fmt.Sprintf("  Strength:     Lv.%d (XP: %s)", attrs.Strength.Level, models.FormatNumber(attrs.Strength.XP)))
+	lines = append(lines, fmt.Sprintf("  Dexterity:    Lv.%d (XP: %s)", attrs.Dexterity.Level, models.FormatNumber(attrs.Dexterity.XP)))
+	lines = append(lines, fmt.Sprintf("  Defense:      Lv.%d (XP: %s)", attrs.Defense.Level, models.FormatNumber(attrs.Defense.XP)))
+	lines = append(lines, fmt.Sprintf("  Constitution: Lv.%d (XP: %s)", attrs.Constitution.Level, models.FormatNumber(attrs.Constitution.XP)))
+	lines = append(lines, fmt.Sprintf("  Intelligence: Lv.%d (XP: %s)", attrs.Intelligence.Level, models.FormatNumber(attrs.Intelligence.XP)))
 	lines = append(lines, "")
 
 	// Derived Stats
@@ -1060,14 +1118,28 @@ func renderCharacterSheet(m *engine.Model, height int) string {
 	// Slayer Info
 	lines = append(lines, categoryStyle.Render("🗡️ Slayer"))
 	lines = append(lines, fmt.Sprintf("  Slayer Level: %d", player.CombatStats.SlayerLevel))
-	lines = append(lines, fmt.Sprintf("  Slayer XP:    %s", formatNumber(player.CombatStats.SlayerXP)))
+	lines = append(lines, fmt.Sprintf("  Slayer XP:    %s", models.FormatNumber(player.CombatStats.SlayerXP)))
 	lines = append(lines, fmt.Sprintf("  Slayer Points: %d", player.CombatStats.SlayerPoints))
 	lines = append(lines, "")
 
+	// Offline cap
+	lines = append(lines, categoryStyle.Render("💤 Offline Progress"))
+	lines = append(lines, fmt.Sprintf("  Offline Cap:  %s", formatPlaytime(m.OfflineProcessor.CapFor(player))))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("    Base %s + %s upgrades (%d/%d) + %s perks",
+		formatPlaytime(m.OfflineProcessor.MaxOfflineTime), formatPlaytime(player.OfflineCapUpgradeBonus()),
+		player.OfflineCapUpgrades, models.MaxOfflineCapUpgrades, formatPlaytime(player.OfflineCapPerkBonus()))))
+	upgradeKey := ""
+	if player.OfflineCapUpgrades < models.MaxOfflineCapUpgrades {
+		cost := models.OfflineCapUpgradeCost(player.OfflineCapUpgrades)
+		lines = append(lines, fmt.Sprintf("  Next upgrade: +%s for %s gold", formatPlaytime(models.OfflineCapUpgradeStep), models.FormatNumber(cost)))
+		upgradeKey = "[o] Upgrade Offline Cap  "
+	}
+	lines = append(lines, "")
+
 	lines = append(lines, lipgloss.NewStyle().
 		Background(lipgloss.Color("#333333")).
 		Foreground(colorInfo).
-		Render("  [n] Change Name  [Esc/d] Dashboard  "))
+		Render("  "+upgradeKey+"[n] Change Name  [Esc/d] Dashboard  "))
 
 	return boxStyle.
 		Height(height).
@@ -1199,7 +1271,7 @@ func renderSlayerMonsterSelection(m *engine.Model, height int) string {
 			lines = append(lines, line)
 			lines = append(lines, fmt.Sprintf("       %s", monster.Description))
 			lines = append(lines, fmt.Sprintf("       Attack:%d Defense:%d Strength:%d", monster.Attack, monster.Defense, monster.Strength))
-			lines = append(lines, fmt.Sprintf("       XP: %s combat, %s slayer", formatNumber(monster.CombatXP), formatNumber(monster.SlayerXP)))
+			lines = append(lines, fmt.Sprintf("       XP: %s combat, %s slayer", models.FormatNumber(monster.CombatXP), models.FormatNumber(monster.SlayerXP)))
 			lines = append(lines, "")
 		} else {
 			hotkey = hotkeyStyle.Render(string(hotkeyLetter))
@@ -1222,11 +1294,11 @@ func renderSlayerMonsterSelection(m *engine.Model, height int) string {
 
 // renderCombat renders the active combat screen
 func renderCombat(m *engine.Model, height int) string {
-	if m.CurrentCombatEncounter == nil {
+	if m.Game.Fight == nil {
 		return boxStyle.Render("Error: No active combat")
 	}
 
-	encounter := m.CurrentCombatEncounter
+	encounter := m.Game.Fight
 	monster := encounter.Monster
 
 	var lines []string
@@ -1303,6 +1375,203 @@ func renderCombat(m *engine.Model, height int) string {
 		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
+// renderRecovery renders the damaged-save report and new game confirmation
+func renderRecovery(m *engine.Model, height int) string {
+	report := m.Recovery
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(" ⚠️ Save Recovery "))
+	lines = append(lines, "")
+
+	for _, line := range report.Lines() {
+		lines = append(lines, "  "+line)
+	}
+	lines = append(lines, "")
+
+	if report.Recovered() {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorPrimary).Render("  Your progress was restored from the backup above."))
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().
+			Background(lipgloss.Color("#333333")).
+			Foreground(colorInfo).
+			Render("  [Enter] Continue  "))
+	} else {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorDanger).Bold(true).Render("  Start a new game? Your damaged save will be kept aside."))
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().
+			Background(lipgloss.Color("#333333")).
+			Foreground(colorInfo).
+			Render("  [y] Start New Game  [n] Quit Without Saving  "))
+	}
+
+	return boxStyle.
+		Height(height).
+		Width(m.Width - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// renderBackupRestore renders the backup list with a preview of the selected backup
+func renderBackupRestore(m *engine.Model, height int) string {
+	backups := m.RestoreState.Backups
+
+	var list []string
+	list = append(list, headerStyle.Render(" 🗄️ Restore Backup "))
+	list = append(list, "")
+
+	if len(backups) == 0 {
+		list = append(list, dimStyle.Render("  No backups yet. One is made every time the game saves."))
+	}
+	for i, backup := range backups {
+		row := fmt.Sprintf("  %-9s %s", backup.Tier, backup.Time.Format("2006-01-02 15:04"))
+		if i == m.CursorPosition {
+			list = append(list, selectedStyle.Render("▶"+row[1:]))
+		} else {
+			list = append(list, activityStyle.Render(row))
+		}
+	}
+
+	var preview []string
+	if backup, loaded := m.SelectedBackupPreview(); loaded != nil {
+		preview = append(preview, categoryStyle.Render("🔍 Preview"))
+		preview = append(preview, dimStyle.Render("  "+backup.Path))
+		preview = append(preview, "")
+		if loaded.Err != nil {
+			preview = append(preview, lipgloss.NewStyle().Foreground(colorDanger).Render(fmt.Sprintf("  Unreadable: %v", loaded.Err)))
+		} else {
+			player := loaded.Player
+			preview = append(preview, "  "+labelStyle.Render(player.String()))
+			preview = append(preview, fmt.Sprintf("  💰 %s gold", models.FormatNumber(player.Gold)))
+			preview = append(preview, fmt.Sprintf("  Last online: %s", player.LastOnline.Format("2006-01-02 15:04")))
+			preview = append(preview, "")
+			for _, skillType := range skillDisplayOrder {
+				skill := player.GetSkill(skillType)
+				preview = append(preview, fmt.Sprintf("  %s %-12s Lv.%d", getSkillIcon(skillType), models.SkillNames[skillType], skill.Level))
+			}
+		}
+	}
+
+	var footer string
+	if m.RestoreState.ShowConfirmation {
+		footer = lipgloss.NewStyle().Foreground(colorDanger).Bold(true).
+			Render("  Roll back to this backup? Your current game is saved first. [y/n]  ")
+	} else {
+		footer = lipgloss.NewStyle().
+			Background(lipgloss.Color("#333333")).
+			Foreground(colorInfo).
+			Render("  [↑/↓] Select  [Enter] Restore  [Esc] Back  ")
+	}
+
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(32).Render(lipgloss.JoinVertical(lipgloss.Left, list...)),
+		lipgloss.JoinVertical(lipgloss.Left, preview...))
+
+	return boxStyle.
+		Height(height).
+		Width(m.Width - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, columns, "", footer))
+}
+
+// renderSaveCode renders the save code export/import screen
+func renderSaveCode(m *engine.Model, height int) string {
+	state := m.SaveCode
+	wrapWidth := m.Width - 12
+	if wrapWidth < 20 {
+		wrapWidth = 20
+	}
+
+	var lines []string
+	var controls string
+
+	switch state.Mode {
+	case engine.SaveCodeExport:
+		lines = append(lines, headerStyle.Render(" 📤 Export Save Code "))
+		lines = append(lines, "")
+		lines = append(lines, "Copy this code and import it on another machine:")
+		lines = append(lines, "")
+		if state.Code != "" {
+			for _, chunk := range wrapText(state.Code, wrapWidth) {
+				lines = append(lines, "  "+activityStyle.Render(chunk))
+			}
+		}
+		controls = "  [f] Write to file  [i] Import a code  [Esc] Back  "
+
+	case engine.SaveCodeImport:
+		lines = append(lines, headerStyle.Render(" 📥 Import Save Code "))
+		lines = append(lines, "")
+		lines = append(lines, "Paste a save code and press Enter:")
+		lines = append(lines, "")
+
+		// Only the end of a long paste fits on screen
+		input := state.Input
+		const shown = 400
+		if len(input) > shown {
+			input = "…" + input[len(input)-shown:]
+		}
+		inputBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(colorHighlight).
+			Padding(0, 1).
+			Width(wrapWidth + 4).
+			Render(strings.Join(wrapText(input+"▌", wrapWidth), "\n"))
+		lines = append(lines, inputBox)
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d characters", len(state.Input))))
+		controls = "  [Enter] Check code  [Ctrl+U] Clear  [Esc] Back  "
+
+	case engine.SaveCodeConfirm:
+		player := state.Imported
+		lines = append(lines, headerStyle.Render(" 📥 Import Save Code "))
+		lines = append(lines, "")
+		lines = append(lines, "  "+labelStyle.Render(player.String()))
+		lines = append(lines, fmt.Sprintf("  💰 %s gold", models.FormatNumber(player.Gold)))
+		lines = append(lines, fmt.Sprintf("  Playtime: %s", formatPlaytime(player.TotalPlaytime)))
+		lines = append(lines, fmt.Sprintf("  Last online: %s", player.LastOnline.Format("2006-01-02 15:04")))
+		lines = append(lines, "")
+		for _, skillType := range skillDisplayOrder {
+			skill := player.GetSkill(skillType)
+			lines = append(lines, fmt.Sprintf("  %s %-12s Lv.%d", getSkillIcon(skillType), models.SkillNames[skillType], skill.Level))
+		}
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorDanger).Bold(true).
+			Render("  Replace your current game with this character? It is saved first and can be restored with Ctrl+R. [y/n]"))
+	}
+
+	if state.Err != nil {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorDanger).Render(fmt.Sprintf("  %v", state.Err)))
+	}
+	if state.Notice != "" {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorHighlight).Render("  "+state.Notice))
+	}
+
+	if controls != "" {
+		lines = append(lines, "")
+		lines = append(lines, lipgloss.NewStyle().
+			Background(lipgloss.Color("#333333")).
+			Foreground(colorInfo).
+			Render(controls))
+	}
+
+	return boxStyle.
+		Height(height).
+		Width(m.Width - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// wrapText splits text into lines of at most width runes
+func wrapText(text string, width int) []string {
+	runes := []rune(text)
+	var lines []string
+	for len(runes) > width {
+		lines = append(lines, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(lines, string(runes))
+}
+
+// skillDisplayOrder is the order skills are listed in previews
+var skillDisplayOrder = models.SkillTypes
+
 // Helper function to get monsters for tier
 func getMonstersForTierUI(tier int) []*models.Monster {
 	var minLevel, maxLevel int
@@ -1416,16 +1685,3 @@ func overlayMessage(content string, message string, width int, height int) strin
 
 	return content + "\n\n" + msg
 }
-
-func formatNumber(n int64) string {
-	if n >= 1000000000 {
-		return fmt.Sprintf("%.1fB", float64(n)/1000000000)
-	}
-	if n >= 1000000 {
-		return fmt.Sprintf("%.1fM", float64(n)/1000000)
-	}
-	if n >= 1000 {
-		return fmt.Sprintf("%.1fK", float64(n)/1000)
-	}
-	return fmt.Sprintf("%d", n)
-}
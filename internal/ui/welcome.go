@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+
+	"afk-tui/internal/engine"
+	"afk-tui/internal/models"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderWelcomeBack renders the offline progress report shown on startup
+func renderWelcomeBack(m *engine.Model, height int) string {
+	state := m.WelcomeBack
+	report := state.Report
+	if report == nil {
+		return boxStyle.Height(height).Width(m.Width - 4).Render("")
+	}
+
+	warnStyle := lipgloss.NewStyle().Foreground(colorDanger).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render(" 👋 Welcome back! "))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("You were away for %s", labelStyle.Render(formatPlaytime(report.OfflineTime))))
+	if report.Discarded > 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorWarning).Render(
+			fmt.Sprintf("  Offline cap reached: another %s away was not counted. Raise the cap on the character sheet [c].",
+				formatPlaytime(report.Discarded))))
+	}
+	if report.InCombat {
+		lines = append(lines, fmt.Sprintf("  ⚔️  %s: %d kills, %d deaths", report.ActivityName, report.Kills, report.Deaths))
+	} else {
+		lines = append(lines, fmt.Sprintf("  %s %s: %d actions", getSkillIcon(report.SkillType), report.ActivityName, report.ActionsCompleted))
+	}
+	if report.StopReason != "" {
+		lines = append(lines, warnStyle.Render(fmt.Sprintf("  Stopped after %s: %s", formatPlaytime(report.StoppedAfter), report.StopReason)))
+	}
+
+	// Experience per skill, then attributes and slayer
+	lines = append(lines, "")
+	lines = append(lines, categoryStyle.Render("📈 Experience"))
+	gainedXP := false
+	for _, skillType := range skillDisplayOrder {
+		xp := report.SkillXP[skillType]
+		if xp == 0 {
+			continue
+		}
+		gainedXP = true
+		row := fmt.Sprintf("  %s %-12s %9s XP  Lv.%d", getSkillIcon(skillType), models.SkillNames[skillType],
+			"+"+models.FormatNumber(xp), m.Player.GetSkill(skillType).Level)
+		if levels := report.LevelsGained[skillType]; levels > 0 {
+			row += tier1Style.Render(fmt.Sprintf(" (+%d)", levels))
+		}
+		lines = append(lines, row)
+	}
+	if report.Attribute != "" && report.AttributeXP > 0 {
+		gainedXP = true
+		row := fmt.Sprintf("  💪 %-12s %9s XP", report.Attribute, "+"+models.FormatNumber(report.AttributeXP))
+		if report.AttributeLevels > 0 {
+			row += tier1Style.Render(fmt.Sprintf(" (+%d)", report.AttributeLevels))
+		}
+		lines = append(lines, row)
+	}
+	if report.SlayerXP > 0 {
+		gainedXP = true
+		row := fmt.Sprintf("  💀 %-12s %9s XP", "Slayer", "+"+models.FormatNumber(report.SlayerXP))
+		if report.SlayerLevels > 0 {
+			row += tier1Style.Render(fmt.Sprintf(" (+%d)", report.SlayerLevels))
+		}
+		lines = append(lines, row)
+	}
+	if !gainedXP {
+		lines = append(lines, dimStyle.Render("  No experience gained"))
+	}
+
+	if len(report.PerksUnlocked) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, categoryStyle.Render("✨ Perks Unlocked"))
+		for _, perk := range report.PerksUnlocked {
+			lines = append(lines, fmt.Sprintf("  %s - %s", labelStyle.Render(perk.Name), dimStyle.Render(perk.Description)))
+		}
+	}
+
+	// Loot table, scrolled to fit the space left
+	loot := report.Loot()
+	lines = append(lines, "")
+	lines = append(lines, categoryStyle.Render("🎒 Loot"))
+	if len(loot) == 0 {
+		lines = append(lines, dimStyle.Render("  No items"))
+	} else {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %-24s %8s %8s %10s", "Item", "Qty", "Each", "Value")))
+
+		// Room for the rows: box padding, totals, lost warning and controls
+		rows := height - len(lines) - 9
+		if rows < 3 {
+			rows = 3
+		}
+		start := min(state.Scroll, max(len(loot)-rows, 0))
+		end := min(start+rows, len(loot))
+
+		lostAny := false
+		for _, line := range loot {
+			if line.Lost > 0 {
+				lostAny = true
+			}
+		}
+
+		for _, line := range loot[start:end] {
+			each, value := "-", "-"
+			if line.Value > 0 {
+				each = models.FormatNumber(line.Value)
+				value = models.FormatNumber(int64(line.Gained) * line.Value)
+			}
+			if line.Gained > 0 {
+				lines = append(lines, fmt.Sprintf("  %-24s %8s %8s %10s", line.Name, models.FormatNumber(int64(line.Gained)), each, value))
+			}
+			if line.Sold > 0 {
+				lines = append(lines, dimStyle.Render(fmt.Sprintf("  $ %-22s %8s  sold - inventory full", line.Name, models.FormatNumber(int64(line.Sold)))))
+			}
+			if line.Banked > 0 {
+				lines = append(lines, dimStyle.Render(fmt.Sprintf("  ⇢ %-22s %8s  to bank - inventory full", line.Name, models.FormatNumber(int64(line.Banked)))))
+			}
+			if line.Lost > 0 {
+				lines = append(lines, warnStyle.Render(fmt.Sprintf("  ⚠ %-22s %8s  LOST - inventory full", line.Name, models.FormatNumber(int64(line.Lost)))))
+			}
+		}
+		if start > 0 || end < len(loot) {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  Showing %d-%d of %d", start+1, end, len(loot))))
+		}
+
+		total := fmt.Sprintf("  Loot value: %s gold", models.FormatNumber(report.LootValue()))
+		if report.GoldGained > 0 {
+			total += fmt.Sprintf("  +%s gold from kills", models.FormatNumber(report.GoldGained))
+		}
+		if report.OverflowGold > 0 {
+			total += fmt.Sprintf("  +%s gold from overflow sold", models.FormatNumber(report.OverflowGold))
+		}
+		lines = append(lines, labelStyle.Render(total))
+		if lostAny {
+			lines = append(lines, warnStyle.Render("  Some items were lost because your inventory was full. Choose what happens to them in the inventory [f]."))
+		}
+	}
+	if len(loot) == 0 && report.GoldGained > 0 {
+		lines = append(lines, labelStyle.Render(fmt.Sprintf("  +%s gold from kills", models.FormatNumber(report.GoldGained))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, lipgloss.NewStyle().
+		Background(lipgloss.Color("#333333")).
+		Foreground(colorInfo).
+		Render("  [Enter] Continue  [i] Inventory  [↑/↓] Scroll loot  "))
+
+	return boxStyle.
+		Height(height).
+		Width(m.Width - 4).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}